@@ -1,7 +1,7 @@
 package handlers
 
 import (
-	"crypto/md5"
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -9,131 +9,205 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/singleflight"
 
 	"placeintel-pro/api/models"
+	"placeintel-pro/api/realtime"
 	"placeintel-pro/api/services"
 )
 
 // PlaceHandler handles place-related API endpoints
 type PlaceHandler struct {
-	foursquareService   *services.FoursquareService
+	placesProvider      services.PlacesProvider
 	intelligenceService *services.IntelligenceService
 	cacheService        *services.CacheService
+	trendsService       *services.TrendsService
+	realtimePoller      *realtime.Poller
+
+	// searchFlight collapses duplicate concurrent SearchPlaces requests for
+	// the same cache key within this process, on top of the cross-instance
+	// coordination CacheService does via its Redis search lock.
+	searchFlight singleflight.Group
 }
 
-// NewPlaceHandler creates a new place handler instance
+// NewPlaceHandler creates a new place handler instance. placesProvider is
+// whichever PlacesProvider implementation the router selected (Foursquare,
+// Google Places, OSM, or a MultiProvider composing several).
 func NewPlaceHandler(
-	foursquareService *services.FoursquareService,
+	placesProvider services.PlacesProvider,
 	intelligenceService *services.IntelligenceService,
 	cacheService *services.CacheService,
+	trendsService *services.TrendsService,
+	realtimePoller *realtime.Poller,
 ) *PlaceHandler {
 	return &PlaceHandler{
-		foursquareService:   foursquareService,
+		placesProvider:      placesProvider,
 		intelligenceService: intelligenceService,
 		cacheService:        cacheService,
+		trendsService:       trendsService,
+		realtimePoller:      realtimePoller,
 	}
 }
 
+// searchOutcome is what fetchAndCacheSearchResults (and the cache-hit path it
+// short-circuits) hands back to SearchPlaces, so the response's data_sources
+// can reflect whether the results came from cache.
+type searchOutcome struct {
+	places []models.PlaceIntelligence
+	cached bool
+}
+
 // SearchPlaces handles place search requests
 func (ph *PlaceHandler) SearchPlaces(c *gin.Context) {
 	startTime := time.Now()
 
-	// Parse and validate request parameters
-	var req models.PlaceSearchRequest
+	req, ok := ph.bindAndValidateSearchRequest(c)
+	if !ok {
+		return
+	}
+
+	outcome, err := ph.resolveSearchOutcome(c.Request.Context(), req)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to search places")
+		code := services.ErrorResponseCode(err)
+		c.JSON(code, models.ErrorResponse{
+			Error:   "Failed to search places",
+			Message: err.Error(),
+			Code:    code,
+		})
+		return
+	}
+
+	dataSources := []string{"foursquare", "intelligence"}
+	if outcome.cached {
+		dataSources = []string{"cache", "foursquare", "intelligence"}
+	}
+
+	// Prepare response
+	response := models.PlaceSearchResponse{
+		Results: outcome.places,
+		Meta: struct {
+			Total          int           `json:"total"`
+			ProcessingTime time.Duration `json:"processing_time_ms"`
+			DataSources    []string      `json:"data_sources"`
+		}{
+			Total:          len(outcome.places),
+			ProcessingTime: time.Since(startTime),
+			DataSources:    dataSources,
+		},
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"results":         len(outcome.places),
+		"processing_time": time.Since(startTime),
+		"query":           req.Query,
+		"location":        fmt.Sprintf("%.6f,%.6f", req.Latitude, req.Longitude),
+	}).Info("Place search completed successfully")
+
+	c.JSON(http.StatusOK, response)
+}
+
+// bindAndValidateSearchRequest parses and validates the query parameters
+// shared by SearchPlaces (v1) and SearchPlacesV2, writing the 400 response
+// itself on failure so callers only need to check ok.
+func (ph *PlaceHandler) bindAndValidateSearchRequest(c *gin.Context) (req models.PlaceSearchRequest, ok bool) {
 	if err := c.ShouldBindQuery(&req); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Error:   "Invalid request parameters",
 			Message: err.Error(),
 			Code:    http.StatusBadRequest,
 		})
-		return
+		return req, false
 	}
 
-	// Validate required parameters
 	if req.Latitude == 0 || req.Longitude == 0 {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
 			Error:   "Missing required parameters",
 			Message: "Latitude and longitude are required",
 			Code:    http.StatusBadRequest,
 		})
-		return
+		return req, false
 	}
 
-	// Generate cache key
-	cacheKey := ph.generateSearchCacheKey(req)
+	return req, true
+}
 
-	// Try to get results from cache first
-	if cachedResults, err := ph.cacheService.GetCachedSearchResults(cacheKey); err == nil && cachedResults != nil {
+// resolveSearchOutcome looks up cached results by S2 cell (so nearby
+// queries at a similar radius can share a cache entry without needing
+// identical coordinates), then falls through to the single-flight
+// coordinated fetch on a miss. Shared by SearchPlaces (v1) and
+// SearchPlacesV2.
+func (ph *PlaceHandler) resolveSearchOutcome(ctx context.Context, req models.PlaceSearchRequest) (searchOutcome, error) {
+	cachedResults, cacheKey, err := ph.cacheService.GetCachedSearchResultsNear(
+		ctx, req.Latitude, req.Longitude, req.Radius, req.Query, req.Categories, req.Limit,
+	)
+	if err == nil && cachedResults != nil {
 		logrus.WithField("cache_key", cacheKey).Info("Returning cached search results")
-		
-		response := models.PlaceSearchResponse{
-			Results: cachedResults,
-			Meta: struct {
-				Total          int           `json:"total"`
-				ProcessingTime time.Duration `json:"processing_time_ms"`
-				DataSources    []string      `json:"data_sources"`
-			}{
-				Total:          len(cachedResults),
-				ProcessingTime: time.Since(startTime),
-				DataSources:    []string{"cache", "foursquare", "intelligence"},
-			},
+		return searchOutcome{places: cachedResults, cached: true}, nil
+	}
+
+	// Collapse duplicate concurrent requests for this cache key within this
+	// process, then single-flight across instances via a Redis lock so only
+	// one instance calls the places provider on a cold miss.
+	v, err, _ := ph.searchFlight.Do(cacheKey, func() (interface{}, error) {
+		return ph.fetchAndCacheSearchResults(ctx, req, cacheKey)
+	})
+	if err != nil {
+		return searchOutcome{}, err
+	}
+	return v.(searchOutcome), nil
+}
+
+// fetchAndCacheSearchResults runs the cross-instance single-flight dance for
+// a cold cache miss: try to become the instance that calls the places
+// provider; if another instance already is, poll briefly for it to populate
+// the cache before falling through to fetch ourselves anyway, so a stuck
+// lock holder never stalls the request past searchLockMaxWait.
+func (ph *PlaceHandler) fetchAndCacheSearchResults(ctx context.Context, req models.PlaceSearchRequest, cacheKey string) (searchOutcome, error) {
+	// Re-check the cache: another goroutine in this process, or another
+	// instance, may have populated it between the first miss and now.
+	if results, err := ph.cacheService.GetCachedSearchResults(ctx, cacheKey); err == nil && results != nil {
+		return searchOutcome{places: results, cached: true}, nil
+	}
+
+	release, acquired, lockErr := ph.cacheService.TryAcquireSearchLock(ctx, cacheKey)
+	if lockErr != nil {
+		logrus.WithError(lockErr).Warn("Search single-flight lock unavailable, proceeding without cross-instance coordination")
+	}
+	if lockErr == nil && !acquired {
+		if results, ok := ph.cacheService.WaitForCachedSearchResults(ctx, cacheKey); ok {
+			return searchOutcome{places: results, cached: true}, nil
 		}
-		
-		c.JSON(http.StatusOK, response)
-		return
+	}
+	if lockErr == nil && acquired {
+		defer release()
 	}
 
 	// Search places using Foursquare API
-	places, err := ph.foursquareService.SearchPlaces(req)
+	places, err := ph.placesProvider.SearchPlaces(ctx, req)
 	if err != nil {
-		logrus.WithError(err).Error("Failed to search places")
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Failed to search places",
-			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
-		})
-		return
+		return searchOutcome{}, fmt.Errorf("failed to search places: %w", err)
+	}
+
+	// Record a trend event per result before enhancement can fail midway -
+	// trending data should reflect what was found, not whether intelligence
+	// enrichment succeeded.
+	for _, place := range places {
+		ph.trendsService.RecordPlaceEvent(ctx, place)
 	}
 
 	// Enhance places with intelligence
-	enhancedPlaces, err := ph.intelligenceService.EnhancePlacesWithIntelligence(places)
+	enhancedPlaces, err := ph.intelligenceService.EnhancePlacesWithIntelligence(ctx, places)
 	if err != nil {
-		logrus.WithError(err).Error("Failed to enhance places with intelligence")
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "Failed to enhance places with intelligence",
-			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
-		})
-		return
+		return searchOutcome{}, fmt.Errorf("failed to enhance places with intelligence: %w", err)
 	}
 
 	// Cache the results
-	if err := ph.cacheService.CacheSearchResults(cacheKey, enhancedPlaces); err != nil {
+	if err := ph.cacheService.CacheSearchResults(ctx, cacheKey, enhancedPlaces); err != nil {
 		logrus.WithError(err).Warn("Failed to cache search results")
 	}
 
-	// Prepare response
-	response := models.PlaceSearchResponse{
-		Results: enhancedPlaces,
-		Meta: struct {
-			Total          int           `json:"total"`
-			ProcessingTime time.Duration `json:"processing_time_ms"`
-			DataSources    []string      `json:"data_sources"`
-		}{
-			Total:          len(enhancedPlaces),
-			ProcessingTime: time.Since(startTime),
-			DataSources:    []string{"foursquare", "intelligence"},
-		},
-	}
-
-	logrus.WithFields(logrus.Fields{
-		"results":         len(enhancedPlaces),
-		"processing_time": time.Since(startTime),
-		"query":          req.Query,
-		"location":       fmt.Sprintf("%.6f,%.6f", req.Latitude, req.Longitude),
-	}).Info("Place search completed successfully")
-
-	c.JSON(http.StatusOK, response)
+	return searchOutcome{places: enhancedPlaces, cached: false}, nil
 }
 
 // GetPlaceIntelligence handles requests for place intelligence by search parameters
@@ -144,6 +218,7 @@ func (ph *PlaceHandler) GetPlaceIntelligence(c *gin.Context) {
 
 // GetPlaceDetails handles requests for detailed place information
 func (ph *PlaceHandler) GetPlaceDetails(c *gin.Context) {
+	ctx := c.Request.Context()
 	placeID := c.Param("place_id")
 	if placeID == "" {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -157,26 +232,27 @@ func (ph *PlaceHandler) GetPlaceDetails(c *gin.Context) {
 	startTime := time.Now()
 
 	// Try cache first
-	if cachedDetails, err := ph.cacheService.GetCachedPlaceDetails(placeID); err == nil && cachedDetails != nil {
+	if cachedDetails, err := ph.cacheService.GetCachedPlaceDetails(ctx, placeID); err == nil && cachedDetails != nil {
 		logrus.WithField("place_id", placeID).Info("Returning cached place details")
 		c.JSON(http.StatusOK, cachedDetails)
 		return
 	}
 
 	// Get place details from Foursquare
-	details, err := ph.foursquareService.GetPlaceDetails(placeID)
+	details, err := ph.placesProvider.GetPlaceDetails(ctx, placeID)
 	if err != nil {
 		logrus.WithError(err).WithField("place_id", placeID).Error("Failed to get place details")
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		code := services.ErrorResponseCode(err)
+		c.JSON(code, models.ErrorResponse{
 			Error:   "Failed to get place details",
 			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
+			Code:    code,
 		})
 		return
 	}
 
 	// Cache the details
-	if err := ph.cacheService.CachePlaceDetails(placeID, details); err != nil {
+	if err := ph.cacheService.CachePlaceDetails(ctx, placeID, details); err != nil {
 		logrus.WithError(err).Warn("Failed to cache place details")
 	}
 
@@ -191,6 +267,7 @@ func (ph *PlaceHandler) GetPlaceDetails(c *gin.Context) {
 
 // GetPlaceIntelligenceByID handles requests for place intelligence by place ID
 func (ph *PlaceHandler) GetPlaceIntelligenceByID(c *gin.Context) {
+	ctx := c.Request.Context()
 	placeID := c.Param("place_id")
 	if placeID == "" {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{
@@ -204,20 +281,21 @@ func (ph *PlaceHandler) GetPlaceIntelligenceByID(c *gin.Context) {
 	startTime := time.Now()
 
 	// Try cache first
-	if cachedIntelligence, err := ph.cacheService.GetCachedPlaceIntelligence(placeID); err == nil && cachedIntelligence != nil {
+	if cachedIntelligence, err := ph.cacheService.GetCachedPlaceIntelligence(ctx, placeID); err == nil && cachedIntelligence != nil {
 		logrus.WithField("place_id", placeID).Info("Returning cached place intelligence")
 		c.JSON(http.StatusOK, cachedIntelligence)
 		return
 	}
 
 	// Get place details from Foursquare first
-	details, err := ph.foursquareService.GetPlaceDetails(placeID)
+	details, err := ph.placesProvider.GetPlaceDetails(ctx, placeID)
 	if err != nil {
 		logrus.WithError(err).WithField("place_id", placeID).Error("Failed to get place details for intelligence")
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		code := services.ErrorResponseCode(err)
+		c.JSON(code, models.ErrorResponse{
 			Error:   "Failed to get place details",
 			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
+			Code:    code,
 		})
 		return
 	}
@@ -247,10 +325,29 @@ func (ph *PlaceHandler) GetPlaceIntelligenceByID(c *gin.Context) {
 		},
 		Categories: details.Categories,
 		Distance:   0, // Not applicable for direct place lookup
+
+		Tel:          details.Tel,
+		Website:      details.Website,
+		Email:        details.Email,
+		Hours:        &details.Hours,
+		HoursPopular: details.HoursPopular,
+		Popularity:   details.Popularity,
+		Price:        details.Price,
+		Rating:       details.Rating,
+		Stats:        &details.Stats,
+		Photos:       details.Photos,
+		Tips:         details.Tips,
+		SocialMedia:  details.SocialMedia,
+		Chains:       details.Chains,
+		DateClosed:   details.DateClosed,
+		ClosedBucket: details.ClosedBucket,
+		Features:     details.Features,
 	}
 
+	ph.trendsService.RecordPlaceEvent(ctx, place)
+
 	// Enhance with intelligence
-	intelligence, err := ph.intelligenceService.EnhancePlaceWithIntelligence(place)
+	intelligence, err := ph.intelligenceService.EnhancePlaceWithIntelligence(ctx, place)
 	if err != nil {
 		logrus.WithError(err).WithField("place_id", placeID).Error("Failed to enhance place with intelligence")
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -262,10 +359,14 @@ func (ph *PlaceHandler) GetPlaceIntelligenceByID(c *gin.Context) {
 	}
 
 	// Cache the intelligence
-	if err := ph.cacheService.CachePlaceIntelligence(placeID, intelligence); err != nil {
+	if err := ph.cacheService.CachePlaceIntelligence(ctx, placeID, intelligence); err != nil {
 		logrus.WithError(err).Warn("Failed to cache place intelligence")
 	}
 
+	// A place someone is actively looking up is worth keeping fresh in the
+	// background, so future lookups don't block on a live Foursquare call.
+	ph.realtimePoller.SubscribePlace(placeID, realtime.DefaultInterval)
+
 	logrus.WithFields(logrus.Fields{
 		"place_id":        placeID,
 		"place_name":      intelligence.Name,
@@ -277,6 +378,8 @@ func (ph *PlaceHandler) GetPlaceIntelligenceByID(c *gin.Context) {
 
 // GetPopularPlaces handles requests for popular places in an area
 func (ph *PlaceHandler) GetPopularPlaces(c *gin.Context) {
+	ctx := c.Request.Context()
+
 	// Parse location parameters
 	latStr := c.Query("lat")
 	lngStr := c.Query("lng")
@@ -310,10 +413,13 @@ func (ph *PlaceHandler) GetPopularPlaces(c *gin.Context) {
 		return
 	}
 
-	locationKey := fmt.Sprintf("%.4f,%.4f", lat, lng)
+	// Popular places key on the S2 cell alone: the search below always uses
+	// the same fixed radius, so there's no query/categories/limit variation
+	// to distinguish between cache entries.
+	locationKey := ph.cacheService.PopularPlacesCacheKey(lat, lng)
 
 	// Try cache first
-	if cachedPopular, err := ph.cacheService.GetCachedPopularPlaces(locationKey); err == nil && cachedPopular != nil {
+	if cachedPopular, err := ph.cacheService.GetCachedPopularPlaces(ctx, locationKey); err == nil && cachedPopular != nil {
 		logrus.WithField("location", locationKey).Info("Returning cached popular places")
 		c.JSON(http.StatusOK, gin.H{
 			"popular_places": cachedPopular,
@@ -327,23 +433,24 @@ func (ph *PlaceHandler) GetPopularPlaces(c *gin.Context) {
 	req := models.PlaceSearchRequest{
 		Latitude:  lat,
 		Longitude: lng,
-		Radius:    2000, // 2km radius for popular places
-		Limit:     20,   // Top 20 popular places
+		Radius:    services.PopularPlacesRadius,
+		Limit:     20, // Top 20 popular places
 	}
 
-	places, err := ph.foursquareService.SearchPlaces(req)
+	places, err := ph.placesProvider.SearchPlaces(ctx, req)
 	if err != nil {
 		logrus.WithError(err).Error("Failed to search for popular places")
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		code := services.ErrorResponseCode(err)
+		c.JSON(code, models.ErrorResponse{
 			Error:   "Failed to search for popular places",
 			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
+			Code:    code,
 		})
 		return
 	}
 
 	// Enhance with intelligence and filter for popular ones
-	enhancedPlaces, err := ph.intelligenceService.EnhancePlacesWithIntelligence(places)
+	enhancedPlaces, err := ph.intelligenceService.EnhancePlacesWithIntelligence(ctx, places)
 	if err != nil {
 		logrus.WithError(err).Error("Failed to enhance popular places with intelligence")
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -363,7 +470,7 @@ func (ph *PlaceHandler) GetPopularPlaces(c *gin.Context) {
 	}
 
 	// Cache the results
-	if err := ph.cacheService.CachePopularPlaces(locationKey, popularPlaces); err != nil {
+	if err := ph.cacheService.CachePopularPlaces(ctx, locationKey, popularPlaces); err != nil {
 		logrus.WithError(err).Warn("Failed to cache popular places")
 	}
 
@@ -375,26 +482,86 @@ func (ph *PlaceHandler) GetPopularPlaces(c *gin.Context) {
 	})
 }
 
-// GetTrends handles requests for trending places and insights
+// GetTrends handles requests for trending places and insights near (lat, lng),
+// aggregated from real search/intelligence activity by TrendsService.
 func (ph *PlaceHandler) GetTrends(c *gin.Context) {
-	// This is a placeholder for trends analysis
-	// In a real implementation, this would analyze historical data
+	latStr := c.Query("lat")
+	lngStr := c.Query("lng")
+	if latStr == "" || lngStr == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Missing location parameters",
+			Message: "Latitude and longitude are required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	lat, err := strconv.ParseFloat(latStr, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid latitude",
+			Message: "Latitude must be a valid number",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	lng, err := strconv.ParseFloat(lngStr, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid longitude",
+			Message: "Longitude must be a valid number",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	snapshot, err := ph.trendsService.GetTrends(c.Request.Context(), lat, lng)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to compute trends")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to compute trends",
+			Message: err.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"trends": gin.H{
-			"trending_categories": []string{"coffee", "restaurants", "fitness"},
-			"peak_hours":         []string{"12:00-14:00", "18:00-20:00"},
-			"popular_areas":      []string{"downtown", "business district"},
+			"trending_categories": snapshot.TrendingCategories,
+			"peak_hours":          snapshot.PeakHours,
+			"popular_areas":       snapshot.PopularAreas,
 		},
-		"message": "Trends analysis coming soon",
 	})
 }
 
-// generateSearchCacheKey creates a unique cache key for search requests
-func (ph *PlaceHandler) generateSearchCacheKey(req models.PlaceSearchRequest) string {
-	key := fmt.Sprintf("%.6f,%.6f,%s,%s,%d,%d",
-		req.Latitude, req.Longitude, req.Query, req.Categories, req.Radius, req.Limit)
-	
-	// Create MD5 hash for shorter, consistent keys
-	hash := md5.Sum([]byte(key))
-	return fmt.Sprintf("%x", hash)
-}
\ No newline at end of file
+// GetPlaceTrendHistory returns a place's hour-of-day activity time series
+// for charting.
+func (ph *PlaceHandler) GetPlaceTrendHistory(c *gin.Context) {
+	placeID := c.Param("place_id")
+	if placeID == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Missing place ID",
+			Message: "Place ID is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	history, err := ph.trendsService.GetPlaceHistory(c.Request.Context(), placeID)
+	if err != nil {
+		logrus.WithError(err).WithField("place_id", placeID).Error("Failed to get place trend history")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "Failed to get place trend history",
+			Message: err.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"place_id": placeID,
+		"history":  history,
+	})
+}
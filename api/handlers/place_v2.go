@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"placeintel-pro/api/models"
+	"placeintel-pro/api/services"
+)
+
+// v2DefaultPageSize is the page size SearchPlacesV2 uses when the caller
+// doesn't set limit.
+const v2DefaultPageSize = 10
+
+// v2MaxFetchSize bounds how many results SearchPlacesV2 fetches upstream
+// per request, since the underlying PlacesProvider API has no offset
+// parameter to page through - see paginateSearchResults.
+const v2MaxFetchSize = 50
+
+// SearchPlacesV2 handles v2 place search requests: same underlying search
+// as SearchPlaces, but returns the strongly-typed PlaceIntelligenceV2 shape
+// with cursor-based pagination instead of interface{} Location/Categories.
+// /v1 keeps returning the untyped PlaceSearchResponse for backward
+// compatibility.
+func (ph *PlaceHandler) SearchPlacesV2(c *gin.Context) {
+	ctx := c.Request.Context()
+	startTime := time.Now()
+
+	req, ok := ph.bindAndValidateSearchRequest(c)
+	if !ok {
+		return
+	}
+
+	offset, err := decodeSearchCursor(c.Query("cursor"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid cursor",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	pageSize := req.Limit
+	if pageSize <= 0 {
+		pageSize = v2DefaultPageSize
+	}
+
+	// Pagination here windows over a single upstream fetch rather than true
+	// server-side offset paging, since the underlying PlacesProvider API
+	// (Foursquare, Google, OSM) has no offset parameter to page through.
+	fetchReq := req
+	fetchReq.Limit = v2MaxFetchSize
+	outcome, err := ph.resolveSearchOutcome(ctx, fetchReq)
+	if err != nil {
+		logrus.WithError(err).Error("Failed to search places (v2)")
+		code := services.ErrorResponseCode(err)
+		c.JSON(code, models.ErrorResponse{
+			Error:   "Failed to search places",
+			Message: err.Error(),
+			Code:    code,
+		})
+		return
+	}
+
+	page, nextCursor, err := paginateSearchResults(outcome.places, offset, pageSize)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "Invalid cursor",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	resultsV2 := make([]models.PlaceIntelligenceV2, 0, len(page))
+	for _, place := range page {
+		v2, err := place.ToV2()
+		if err != nil {
+			logrus.WithError(err).WithField("place_id", place.FSQId).Warn("Failed to convert place intelligence to v2, skipping")
+			continue
+		}
+		resultsV2 = append(resultsV2, v2)
+	}
+
+	dataSources := []string{"foursquare", "intelligence"}
+	if outcome.cached {
+		dataSources = []string{"cache", "foursquare", "intelligence"}
+	}
+
+	response := models.PlaceSearchResponseV2{
+		Results: resultsV2,
+		Meta: struct {
+			Total          int           `json:"total"`
+			ProcessingTime time.Duration `json:"processing_time_ms"`
+			DataSources    []string      `json:"data_sources"`
+		}{
+			Total:          len(outcome.places),
+			ProcessingTime: time.Since(startTime),
+			DataSources:    dataSources,
+		},
+		Pagination: models.PaginationCursors{
+			NextCursor: nextCursor,
+			PrevCursor: encodeSearchCursorIfPositive(offset - pageSize),
+		},
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// decodeSearchCursor parses a SearchPlacesV2 cursor into the offset it
+// encodes. An empty cursor means "start from the beginning".
+func decodeSearchCursor(raw string) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+
+	decoded, err := base64.URLEncoding.DecodeString(raw)
+	if err != nil {
+		return 0, fmt.Errorf("malformed cursor")
+	}
+
+	offset, err := strconv.Atoi(string(decoded))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("malformed cursor")
+	}
+
+	return offset, nil
+}
+
+// encodeSearchCursor renders offset as the opaque cursor string clients
+// pass back in to resume from that point.
+func encodeSearchCursor(offset int) string {
+	return base64.URLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// encodeSearchCursorIfPositive is encodeSearchCursor for
+// PaginationCursors.PrevCursor, which should be empty (not "offset 0" or
+// negative) once the caller is already on the first page.
+func encodeSearchCursorIfPositive(offset int) string {
+	if offset <= 0 {
+		return ""
+	}
+	return encodeSearchCursor(offset)
+}
+
+// paginateSearchResults windows results to [offset, offset+pageSize),
+// returning the next page's cursor, or "" once there's nothing left.
+func paginateSearchResults(results []models.PlaceIntelligence, offset, pageSize int) ([]models.PlaceIntelligence, string, error) {
+	if offset > len(results) {
+		return nil, "", fmt.Errorf("cursor is past the end of the result set")
+	}
+
+	end := offset + pageSize
+	if end > len(results) {
+		end = len(results)
+	}
+
+	nextCursor := ""
+	if end < len(results) {
+		nextCursor = encodeSearchCursor(end)
+	}
+
+	return results[offset:end], nextCursor, nil
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -14,7 +15,10 @@ import (
 
 	"placeintel-pro/api/handlers"
 	"placeintel-pro/api/middleware"
+	"placeintel-pro/api/models"
+	"placeintel-pro/api/realtime"
 	"placeintel-pro/api/services"
+	"placeintel-pro/api/services/resilience"
 )
 
 func main() {
@@ -29,14 +33,39 @@ func main() {
 		logrus.SetLevel(level)
 	}
 
-	// Initialize services
-	foursquareService := services.NewFoursquareService(getEnv("FOURSQUARE_API_KEY", ""))
-	intelligenceService := services.NewIntelligenceService(getEnv("PYTHON_SERVICE_URL", "http://localhost:5000"))
+	// Initialize tracing
+	shutdownTracing, err := middleware.InitTracing(getEnv("OTEL_SERVICE_NAME", "placeintel-pro"))
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to initialize tracing")
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logrus.WithError(err).Warn("Failed to shut down tracing cleanly")
+		}
+	}()
+
+	// Initialize services. None hold a stored lifetime context any more -
+	// every method takes the caller's context.Context, threaded down from
+	// c.Request.Context() in PlaceHandler, so a client disconnect or the
+	// per-request timeout middleware below cancels the in-flight call
+	// instead of it outliving the request.
+	placesProvider := newPlacesProvider()
 	cacheService := services.NewCacheService(
 		getEnv("REDIS_HOST", "localhost"),
 		getEnv("REDIS_PORT", "6379"),
 		getEnv("REDIS_PASSWORD", ""),
 	)
+	intelligenceService := services.NewIntelligenceService(getEnv("PYTHON_SERVICE_URL", "http://localhost:5000"), cacheService)
+	trendsService := services.NewTrendsService(cacheService.Client())
+	realtimePoller := realtime.NewPoller(placesProvider, cacheService)
+
+	// ready flips to false as soon as shutdown begins, so /health/ready
+	// fails before in-flight work is cancelled and load balancers stop
+	// routing new traffic here.
+	var ready atomic.Bool
+	ready.Store(true)
 
 	// Initialize Gin router
 	if getEnv("GIN_MODE", "debug") == "release" {
@@ -49,40 +78,90 @@ func main() {
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
 	router.Use(middleware.CORS())
-	router.Use(middleware.RateLimit())
+	router.Use(middleware.Tracing())
+	router.Use(middleware.RateLimit(cacheService.Client()))
+
+	// Metrics endpoint for Prometheus scraping
+	router.GET("/metrics", middleware.MetricsHandler())
 
 	// Initialize handlers
-	placeHandler := handlers.NewPlaceHandler(foursquareService, intelligenceService, cacheService)
+	placeHandler := handlers.NewPlaceHandler(placesProvider, intelligenceService, cacheService, trendsService, realtimePoller)
 
 	// API Routes
 	v1 := router.Group("/api/v1")
 	{
-		// Health check
+		// Health check. Reports actual upstream reachability rather than
+		// just "the process is up" - see health/live below for that.
 		v1.GET("/health", func(c *gin.Context) {
-			c.JSON(http.StatusOK, gin.H{
-				"status":    "healthy",
-				"timestamp": time.Now().UTC(),
-				"version":   "1.0.0",
-			})
+			ctx := c.Request.Context()
+
+			resp := models.HealthResponse{
+				Status:    "healthy",
+				Timestamp: time.Now().UTC(),
+				Version:   "1.0.0",
+				Breakers:  resilience.DefaultRegistry.Snapshot(),
+			}
+			resp.Services.Foursquare = serviceHealthString(placesProvider.HealthCheck(ctx))
+			resp.Services.Intelligence = serviceHealthString(intelligenceService.HealthCheck(ctx))
+			resp.Services.Cache = serviceHealthString(cacheService.HealthCheck(ctx))
+			resp.Services.Realtime = realtimePoller.Health()
+			if mp, ok := placesProvider.(*services.MultiProvider); ok {
+				resp.Services.Providers = mp.ProviderHealth(ctx)
+			}
+
+			c.JSON(http.StatusOK, resp)
+		})
+
+		// Liveness: the process is up and serving. Never fails on its own -
+		// an orchestrator should only use this to decide whether to restart
+		// the container, not whether to route traffic to it.
+		v1.GET("/health/live", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"status": "alive"})
 		})
 
-		// Place intelligence endpoints
+		// Readiness: fails once shutdown has begun, so load balancers stop
+		// sending new traffic before in-flight work is cancelled.
+		v1.GET("/health/ready", func(c *gin.Context) {
+			if !ready.Load() {
+				c.JSON(http.StatusServiceUnavailable, gin.H{"status": "shutting_down"})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"status": "ready"})
+		})
+
+		// Place intelligence endpoints. Search-shaped endpoints get the
+		// shorter deadline; single-place lookups fan out to more upstreams
+		// (Foursquare details + intelligence enhancement) so they get more
+		// room.
+		searchTimeout := middleware.TimeoutFromEnv("SEARCH_TIMEOUT", middleware.DefaultSearchTimeout)
+		detailsTimeout := middleware.TimeoutFromEnv("DETAILS_TIMEOUT", middleware.DefaultDetailsTimeout)
+
 		places := v1.Group("/places")
 		{
-			places.GET("/search", placeHandler.SearchPlaces)
-			places.GET("/intelligence", placeHandler.GetPlaceIntelligence)
-			places.GET("/:place_id/details", placeHandler.GetPlaceDetails)
-			places.GET("/:place_id/intelligence", placeHandler.GetPlaceIntelligenceByID)
+			places.GET("/search", searchTimeout, placeHandler.SearchPlaces)
+			places.GET("/intelligence", searchTimeout, placeHandler.GetPlaceIntelligence)
+			places.GET("/:place_id/details", detailsTimeout, placeHandler.GetPlaceDetails)
+			places.GET("/:place_id/intelligence", detailsTimeout, placeHandler.GetPlaceIntelligenceByID)
+			places.GET("/:place_id/trend-history", detailsTimeout, placeHandler.GetPlaceTrendHistory)
 		}
 
 		// Analytics endpoints
 		analytics := v1.Group("/analytics")
 		{
-			analytics.GET("/popular", placeHandler.GetPopularPlaces)
-			analytics.GET("/trends", placeHandler.GetTrends)
+			analytics.GET("/popular", searchTimeout, placeHandler.GetPopularPlaces)
+			analytics.GET("/trends", searchTimeout, placeHandler.GetTrends)
 		}
 	}
 
+	// v2 exposes the strongly-typed PlaceIntelligenceV2 shape (concrete
+	// Location/Categories instead of interface{}) with cursor-based
+	// pagination. v1 keeps returning the untyped shape for backward
+	// compatibility.
+	v2 := router.Group("/api/v2")
+	{
+		v2.GET("/places/search", middleware.TimeoutFromEnv("SEARCH_TIMEOUT", middleware.DefaultSearchTimeout), placeHandler.SearchPlacesV2)
+	}
+
 	// API Documentation
 	router.GET("/docs", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -95,8 +174,10 @@ func main() {
 				"place_intelligence":        "GET /api/v1/places/intelligence",
 				"place_details":             "GET /api/v1/places/:place_id/details",
 				"place_intelligence_by_id":  "GET /api/v1/places/:place_id/intelligence",
+				"place_trend_history":       "GET /api/v1/places/:place_id/trend-history",
 				"popular_places":            "GET /api/v1/analytics/popular",
 				"trends":                    "GET /api/v1/analytics/trends",
+				"search_places_v2":          "GET /api/v2/places/search",
 			},
 		})
 	})
@@ -122,18 +203,67 @@ func main() {
 	<-quit
 	logrus.Info("Shutting down server...")
 
+	// Fail readiness immediately so load balancers stop routing here before
+	// we start cancelling in-flight work.
+	ready.Store(false)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 	if err := srv.Shutdown(ctx); err != nil {
-		logrus.Fatal("Server forced to shutdown:", err)
+		logrus.WithError(err).Error("Server forced to shutdown")
+	}
+
+	// Now that the server has drained in-flight HTTP requests, close the
+	// services' underlying connections.
+	if closer, ok := placesProvider.(interface{ Close() error }); ok {
+		if err := closer.Close(); err != nil {
+			logrus.WithError(err).Warn("Error closing places provider during shutdown")
+		}
+	}
+	if err := intelligenceService.Close(); err != nil {
+		logrus.WithError(err).Warn("Error closing intelligence service during shutdown")
 	}
+	if err := cacheService.Close(); err != nil {
+		logrus.WithError(err).Warn("Error closing cache service during shutdown")
+	}
+	realtimePoller.Stop()
 
 	logrus.Info("Server exited")
 }
 
+// serviceHealthString renders a HealthCheck error into the string shape
+// HealthResponse.Services expects.
+func serviceHealthString(err error) string {
+	if err != nil {
+		return "unhealthy: " + err.Error()
+	}
+	return "healthy"
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
+}
+
+// newPlacesProvider selects the places backend from PLACES_PROVIDER
+// (foursquare|google|osm|multi). Defaults to foursquare, the only backend
+// this service shipped with originally. "multi" fans the search out across
+// every backend with a usable configuration and merges the results.
+func newPlacesProvider() services.PlacesProvider {
+	switch getEnv("PLACES_PROVIDER", "foursquare") {
+	case "google":
+		return services.NewGooglePlacesService(getEnv("GOOGLE_PLACES_API_KEY", ""))
+	case "osm":
+		return services.NewOSMProvider()
+	case "multi":
+		return services.NewMultiProvider(
+			services.NewFoursquareService(getEnv("FOURSQUARE_API_KEY", "")),
+			services.NewGooglePlacesService(getEnv("GOOGLE_PLACES_API_KEY", "")),
+			services.NewOSMProvider(),
+		)
+	default:
+		return services.NewFoursquareService(getEnv("FOURSQUARE_API_KEY", ""))
+	}
 }
\ No newline at end of file
@@ -1,23 +1,67 @@
 package middleware
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/time/rate"
 )
 
-// RateLimiter holds the rate limiter for each client
+// tokenBucketScript atomically refills and debits a Redis-backed token
+// bucket. It reads the current tokens/last-refill timestamp for KEYS[1],
+// refills rate*(now-last) tokens capped at burst, and deducts 1 token if
+// available. Keeping the whole read-refill-write cycle in one EVALSHA avoids
+// the races a naive GET/SET pair would have under concurrent requests.
+const tokenBucketScript = `
+local tokens_key = KEYS[1]
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local data = redis.call('HMGET', tokens_key, 'tokens', 'ts')
+local tokens = tonumber(data[1])
+local last = tonumber(data[2])
+if tokens == nil then
+  tokens = burst
+  last = now
+end
+
+local delta = math.max(0, now - last)
+tokens = math.min(burst, tokens + (delta * rate / 1000.0))
+
+local allowed = 0
+local retry_after_ms = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  retry_after_ms = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call('HMSET', tokens_key, 'tokens', tokens, 'ts', now)
+redis.call('PEXPIRE', tokens_key, math.ceil((burst / rate) * 1000) + 1000)
+
+return {allowed, tokens, retry_after_ms}
+`
+
+// RateLimiter holds the in-memory fallback limiter for a single client.
 type RateLimiter struct {
 	limiter  *rate.Limiter
 	lastSeen time.Time
 }
 
-// ClientRateLimiters holds rate limiters for all clients
+// ClientRateLimiters holds in-memory fallback limiters for all clients. This
+// is only consulted when Redis is unreachable, so the API degrades to
+// per-process limits instead of rejecting every request outright.
 type ClientRateLimiters struct {
 	clients map[string]*RateLimiter
 	mu      sync.RWMutex
@@ -27,70 +71,191 @@ var clientLimiters = &ClientRateLimiters{
 	clients: make(map[string]*RateLimiter),
 }
 
-// RateLimit middleware for API rate limiting
-func RateLimit() gin.HandlerFunc {
-	// Get rate limit configuration from environment
-	requestsStr := os.Getenv("RATE_LIMIT_REQUESTS")
-	if requestsStr == "" {
-		requestsStr = "100"
-	}
-	requests, _ := strconv.Atoi(requestsStr)
+var scriptSHA string
+
+// RateLimit middleware enforces a token-bucket rate limit backed by Redis so
+// the limit is shared across every instance of the API instead of living in
+// a process-local map. If Redis is unreachable the middleware falls back to
+// the previous in-memory limiter so the API stays available.
+func RateLimit(redisClient *redis.Client) gin.HandlerFunc {
+	defaultRate, defaultBurst := limitFromEnv("RATE_LIMIT")
 
-	windowStr := os.Getenv("RATE_LIMIT_WINDOW")
-	if windowStr == "" {
-		windowStr = "3600"
+	if redisClient != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		sha, err := redisClient.ScriptLoad(ctx, tokenBucketScript).Result()
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to load rate limit Lua script into Redis, falling back to in-memory limiter")
+		} else {
+			scriptSHA = sha
+		}
 	}
-	window, _ := strconv.Atoi(windowStr)
 
-	// Calculate rate limit (requests per second)
-	rps := rate.Limit(float64(requests) / float64(window))
-	burst := requests / 10 // Allow burst of 10% of total requests
-
-	return gin.HandlerFunc(func(c *gin.Context) {
-		clientIP := c.ClientIP()
-		
-		// Get or create rate limiter for this client
-		limiter := getRateLimiter(clientIP, rps, burst)
-		
-		if !limiter.Allow() {
+	return func(c *gin.Context) {
+		scope, identifier := rateLimitIdentity(c)
+		limitRate, burst := routeLimitOverride(c.FullPath(), defaultRate, defaultBurst)
+
+		var allowed bool
+		var remaining float64
+		var retryAfterMs int64
+
+		if redisClient != nil && scriptSHA != "" {
+			var err error
+			allowed, remaining, retryAfterMs, err = evalTokenBucket(c.Request.Context(), redisClient, scope, identifier, limitRate, burst)
+			if err != nil {
+				logrus.WithError(err).Warn("Redis rate limiter unavailable, falling back to in-memory limiter")
+				allowed, remaining = inMemoryAllow(identifier, limitRate, burst)
+			}
+		} else {
+			allowed, remaining = inMemoryAllow(identifier, limitRate, burst)
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(burst))
+		c.Header("X-RateLimit-Remaining", strconv.FormatFloat(remaining, 'f', 0, 64))
+
+		if !allowed {
+			if retryAfterMs == 0 {
+				retryAfterMs = int64(1000 / float64(limitRate))
+			}
+			c.Header("Retry-After", strconv.FormatInt(retryAfterMs/1000+1, 10))
 			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error":   "Rate limit exceeded",
-				"message": "Too many requests. Please try again later.",
-				"retry_after": "60s",
+				"error":       "Rate limit exceeded",
+				"message":     "Too many requests. Please try again later.",
+				"retry_after": fmt.Sprintf("%dms", retryAfterMs),
 			})
 			c.Abort()
 			return
 		}
 
 		c.Next()
-	})
+	}
+}
+
+// evalTokenBucket executes the token-bucket Lua script for a single scope/identifier pair.
+func evalTokenBucket(ctx context.Context, redisClient *redis.Client, scope, identifier string, limitRate rate.Limit, burst int) (allowed bool, remaining float64, retryAfterMs int64, err error) {
+	key := fmt.Sprintf("ratelimit:%s:%s", scope, identifier)
+	now := time.Now().UnixMilli()
+
+	res, err := redisClient.EvalSha(ctx, scriptSHA, []string{key}, float64(limitRate), burst, now).Result()
+	if err != nil {
+		return false, 0, 0, fmt.Errorf("token bucket eval failed: %w", err)
+	}
+
+	values, ok := res.([]interface{})
+	if !ok || len(values) != 3 {
+		return false, 0, 0, fmt.Errorf("unexpected token bucket script result: %v", res)
+	}
+
+	allowedInt, _ := values[0].(int64)
+	remaining = toFloat64(values[1])
+	retryAfterInt, _ := values[2].(int64)
+
+	return allowedInt == 1, remaining, retryAfterInt, nil
+}
+
+// toFloat64 reads a Lua script's numeric return value out of the
+// interface{} go-redis hands back. Redis converts a table's Lua numbers to
+// RESP integers - truncating any fraction - so this normally arrives as
+// int64, never float64; the float64 case is kept as a defensive fallback in
+// case that ever changes.
+func toFloat64(v interface{}) float64 {
+	switch n := v.(type) {
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	default:
+		return 0
+	}
+}
+
+// rateLimitIdentity picks the scope/identifier pair the request should be
+// keyed by: an API key if the caller presented one, otherwise the client IP.
+func rateLimitIdentity(c *gin.Context) (scope, identifier string) {
+	if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
+		return "apikey", apiKey
+	}
+	return "ip", c.ClientIP()
+}
+
+// routeLimitOverride returns a per-route rate/burst pair if RATE_LIMIT_ROUTE_<ROUTE>
+// env vars are set, otherwise the service-wide defaults.
+func routeLimitOverride(route string, defaultRate rate.Limit, defaultBurst int) (rate.Limit, int) {
+	if route == "" {
+		return defaultRate, defaultBurst
+	}
+
+	envName := "RATE_LIMIT_ROUTE_" + routeEnvKey(route)
+	if _, requestsSet := os.LookupEnv(envName + "_REQUESTS"); !requestsSet {
+		return defaultRate, defaultBurst
+	}
+
+	return limitFromEnv(envName)
+}
+
+// routeEnvKey turns a gin route template like "/api/v1/places/:place_id/details"
+// into an env-var-safe fragment, e.g. "API_V1_PLACES_PLACE_ID_DETAILS".
+func routeEnvKey(route string) string {
+	replacer := strings.NewReplacer("/", "_", ":", "", "-", "_")
+	return strings.Trim(strings.ToUpper(replacer.Replace(route)), "_")
+}
+
+// limitFromEnv reads "<prefix>_REQUESTS" and "<prefix>_WINDOW" (seconds) and
+// converts them into a requests-per-second rate and a 10%-of-total burst,
+// matching the defaults previously hardcoded in RateLimit.
+func limitFromEnv(prefix string) (rate.Limit, int) {
+	requestsStr := getEnv(prefix+"_REQUESTS", "100")
+	requests, _ := strconv.Atoi(requestsStr)
+	if requests <= 0 {
+		requests = 100
+	}
+
+	windowStr := getEnv(prefix+"_WINDOW", "3600")
+	window, _ := strconv.Atoi(windowStr)
+	if window <= 0 {
+		window = 3600
+	}
+
+	burst := requests / 10
+	if burst < 1 {
+		burst = 1
+	}
+
+	return rate.Limit(float64(requests) / float64(window)), burst
+}
+
+// inMemoryAllow is the process-local fallback limiter used when Redis can't
+// be reached, so the API degrades gracefully instead of rejecting traffic.
+func inMemoryAllow(identifier string, limitRate rate.Limit, burst int) (bool, float64) {
+	limiter := getRateLimiter(identifier, limitRate, burst)
+	return limiter.Allow(), float64(limiter.Tokens())
 }
 
 // getRateLimiter gets or creates a rate limiter for a client
-func getRateLimiter(clientIP string, rps rate.Limit, burst int) *rate.Limiter {
+func getRateLimiter(identifier string, limitRate rate.Limit, burst int) *rate.Limiter {
 	clientLimiters.mu.Lock()
 	defer clientLimiters.mu.Unlock()
 
 	// Clean up old entries (older than 1 hour)
 	now := time.Now()
-	for ip, rl := range clientLimiters.clients {
+	for id, rl := range clientLimiters.clients {
 		if now.Sub(rl.lastSeen) > time.Hour {
-			delete(clientLimiters.clients, ip)
+			delete(clientLimiters.clients, id)
 		}
 	}
 
 	// Get or create rate limiter for this client
-	if rl, exists := clientLimiters.clients[clientIP]; exists {
+	if rl, exists := clientLimiters.clients[identifier]; exists {
 		rl.lastSeen = now
 		return rl.limiter
 	}
 
 	// Create new rate limiter
-	limiter := rate.NewLimiter(rps, burst)
-	clientLimiters.clients[clientIP] = &RateLimiter{
+	limiter := rate.NewLimiter(limitRate, burst)
+	clientLimiters.clients[identifier] = &RateLimiter{
 		limiter:  limiter,
 		lastSeen: now,
 	}
 
 	return limiter
-}
\ No newline at end of file
+}
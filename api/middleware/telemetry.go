@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer is the shared tracer for the PlaceIntel API.
+const TracerName = "placeintel-pro"
+
+// Prometheus RED metrics shared across HTTP handlers and outbound clients.
+var (
+	HTTPRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "placeintel_http_requests_total",
+		Help: "Total HTTP requests handled by the API, by route, method and status.",
+	}, []string{"route", "method", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "placeintel_http_request_duration_seconds",
+		Help:    "Latency of HTTP requests handled by the API, by route and method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	FoursquareRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "placeintel_foursquare_requests_total",
+		Help: "Total outbound requests to the Foursquare API, by endpoint and status.",
+	}, []string{"endpoint", "status"})
+
+	FoursquareRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "placeintel_foursquare_request_duration_seconds",
+		Help:    "Latency of outbound requests to the Foursquare API, by endpoint.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"endpoint"})
+
+	IntelligenceFeatureDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "placeintel_intelligence_feature_duration_seconds",
+		Help:    "Latency of intelligence enhancement calls, by feature.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"feature", "status"})
+)
+
+// InitTracing configures the global OpenTelemetry tracer provider from env vars,
+// mirroring the getEnv-with-default pattern used throughout the service. If
+// OTEL_EXPORTER_OTLP_ENDPOINT is unset, tracing is left as a no-op so local
+// development doesn't require a collector.
+func InitTracing(serviceName string) (func(context.Context) error, error) {
+	endpoint := getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	if endpoint == "" {
+		logrus.Info("OTEL_EXPORTER_OTLP_ENDPOINT not set, tracing disabled")
+		return func(context.Context) error { return nil }, nil
+	}
+
+	ratio, err := strconv.ParseFloat(getEnv("OTEL_TRACES_SAMPLER_RATIO", "1.0"), 64)
+	if err != nil {
+		ratio = 1.0
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(endpoint), otlptracegrpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	logrus.WithFields(logrus.Fields{
+		"endpoint": endpoint,
+		"ratio":    ratio,
+	}).Info("OpenTelemetry tracing initialized")
+
+	return tp.Shutdown, nil
+}
+
+// Tracing starts a server span for every request and records RED metrics.
+// It should be registered before the route handlers so span/metric attributes
+// like place_id are available to reference by request context.
+func Tracing() gin.HandlerFunc {
+	tracer := otel.Tracer(TracerName)
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		ctx, span := tracer.Start(ctx, c.FullPath(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.method", c.Request.Method),
+				attribute.String("http.route", c.FullPath()),
+			),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		route := c.FullPath()
+		status := c.Writer.Status()
+		duration := time.Since(start)
+
+		span.SetAttributes(attribute.Int("http.status_code", status))
+		if status >= 500 {
+			span.SetStatus(codes.Error, "server error")
+		}
+		if placeID := c.Param("place_id"); placeID != "" {
+			span.SetAttributes(attribute.String("place_id", placeID))
+		}
+		if radius := c.Query("radius"); radius != "" {
+			span.SetAttributes(attribute.String("radius", radius))
+		}
+
+		HTTPRequestsTotal.WithLabelValues(route, c.Request.Method, strconv.Itoa(status)).Inc()
+		HTTPRequestDuration.WithLabelValues(route, c.Request.Method).Observe(duration.Seconds())
+	}
+}
+
+// MetricsHandler exposes the Prometheus /metrics endpoint.
+func MetricsHandler() gin.HandlerFunc {
+	h := promhttp.Handler()
+	return func(c *gin.Context) {
+		h.ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Default per-request deadlines. Search-shaped endpoints fan out to a single
+// upstream call; details/intelligence endpoints chain a Foursquare lookup
+// into intelligence enhancement, so they get a longer budget.
+const (
+	DefaultSearchTimeout  = 5 * time.Second
+	DefaultDetailsTimeout = 10 * time.Second
+)
+
+// Timeout wraps the request's context with a deadline of d, so a client
+// disconnect or a slow downstream call (Redis, Foursquare, the intelligence
+// service) is cancelled instead of tying up the handler goroutine after the
+// caller has stopped waiting. Mirrors the deadline pattern used in
+// google/netstack's gonet adapter to bound blocking reads by context.
+func Timeout(d time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// TimeoutFromEnv builds a Timeout middleware from envVar, falling back to def
+// when the variable is unset or not a valid duration.
+func TimeoutFromEnv(envVar string, def time.Duration) gin.HandlerFunc {
+	d := def
+	if raw := os.Getenv(envVar); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil {
+			d = parsed
+		}
+	}
+	return Timeout(d)
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// customTimeLayouts are the timestamp shapes Foursquare's v3 API actually
+// sends across different fields - full RFC3339 with milliseconds on
+// tips/photos created_at, a bare date on date_closed - none of which are a
+// single Go layout, hence trying each in turn.
+var customTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05.000Z0700",
+	"2006-01-02",
+}
+
+// CustomTime wraps time.Time so it can unmarshal any of the timestamp shapes
+// Foursquare's v3 API sends, which the standard library's RFC3339-only
+// time.Time unmarshaler rejects.
+type CustomTime struct {
+	time.Time
+}
+
+func (t *CustomTime) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+
+	var lastErr error
+	for _, layout := range customTimeLayouts {
+		parsed, err := time.Parse(layout, s)
+		if err == nil {
+			t.Time = parsed
+			return nil
+		}
+		lastErr = err
+	}
+	return fmt.Errorf("failed to parse Foursquare timestamp %q: %w", s, lastErr)
+}
+
+func (t CustomTime) MarshalJSON() ([]byte, error) {
+	if t.Time.IsZero() {
+		return []byte(`null`), nil
+	}
+	return []byte(fmt.Sprintf("%q", t.Time.Format(time.RFC3339))), nil
+}
@@ -10,12 +10,24 @@ type PlaceSearchRequest struct {
 	Radius     int     `json:"radius" form:"radius"`
 	Categories string  `json:"categories" form:"categories"`
 	Limit      int     `json:"limit" form:"limit"`
+	// Fields is a comma-separated subset of Foursquare v3 field names (see
+	// services.AllFields) to request, so callers that only need the basics
+	// aren't billed for hours/photos/tips/features on every search. Unknown
+	// names are dropped; empty or fully-invalid falls back to the service's
+	// default field set.
+	Fields string `json:"fields" form:"fields"`
 }
 
 // FoursquarePlace represents basic place data from Foursquare API
 type FoursquarePlace struct {
 	FSQId    string `json:"fsq_id"`
 	Name     string `json:"name"`
+	// Sources records which PlacesProvider backend(s) contributed this
+	// place - a single entry ("foursquare", "google_places", "osm") for a
+	// place as returned by one provider, or several once MultiProvider has
+	// fused matching results from more than one. Not part of the public
+	// JSON shape; intelligence.go folds it into PlaceIntelligence.DataSources.
+	Sources  []string `json:"-"`
 	Location struct {
 		Address     string  `json:"address"`
 		Country     string  `json:"country"`
@@ -35,6 +47,28 @@ type FoursquarePlace struct {
 		} `json:"icon"`
 	} `json:"categories"`
 	Distance int `json:"distance"`
+
+	// Richer Foursquare v3 fields, populated when requested via the
+	// client's fields= parameter builder (see services.fieldsParam).
+	// Pointers/slices are left nil when Foursquare didn't return them,
+	// rather than zero-valuing them, so callers can tell "not requested or
+	// not reported" apart from "reported as empty/false".
+	Tel          string              `json:"tel,omitempty"`
+	Website      string              `json:"website,omitempty"`
+	Email        string              `json:"email,omitempty"`
+	Hours        *PlaceHours         `json:"hours,omitempty"`
+	HoursPopular []PopularHoursBlock `json:"hours_popular,omitempty"`
+	Popularity   float64             `json:"popularity,omitempty"`
+	Price        int                 `json:"price,omitempty"`
+	Rating       float64             `json:"rating,omitempty"`
+	Stats        *PlaceStats         `json:"stats,omitempty"`
+	Photos       []PlacePhoto        `json:"photos,omitempty"`
+	Tips         []PlaceTip          `json:"tips,omitempty"`
+	SocialMedia  *PlaceSocialMedia   `json:"social_media,omitempty"`
+	Chains       []PlaceChain        `json:"chains,omitempty"`
+	DateClosed   *CustomTime         `json:"date_closed,omitempty"`
+	ClosedBucket string              `json:"closed_bucket,omitempty"`
+	Features     *PlaceFeatures      `json:"features,omitempty"`
 }
 
 // BusinessIntelligence represents AI-generated business insights
@@ -89,6 +123,26 @@ type UnifiedRecommendations struct {
 	AccessibilityNotes     []string `json:"accessibility_notes"`
 }
 
+// RawVenueDetails carries the richer Foursquare v3 fields straight through
+// from FoursquarePlace, so BusinessIntelligence/AccessibilityIntelligence can
+// be derived from real API data (price, rating, features, ...) instead of
+// invented defaults, without every consumer having to re-fetch the original
+// search/details response. Left nil when the upstream request didn't
+// include the corresponding fields.
+type RawVenueDetails struct {
+	Hours        *PlaceHours         `json:"hours,omitempty"`
+	HoursPopular []PopularHoursBlock `json:"hours_popular,omitempty"`
+	Popularity   float64             `json:"popularity,omitempty"`
+	Price        int                 `json:"price,omitempty"`
+	Rating       float64             `json:"rating,omitempty"`
+	Stats        *PlaceStats         `json:"stats,omitempty"`
+	Photos       []PlacePhoto        `json:"photos,omitempty"`
+	Tips         []PlaceTip          `json:"tips,omitempty"`
+	SocialMedia  *PlaceSocialMedia   `json:"social_media,omitempty"`
+	Chains       []PlaceChain        `json:"chains,omitempty"`
+	Features     *PlaceFeatures      `json:"features,omitempty"`
+}
+
 // PlaceIntelligence represents the complete enhanced place data
 type PlaceIntelligence struct {
 	// Basic place information
@@ -97,13 +151,17 @@ type PlaceIntelligence struct {
 	Location     interface{}     `json:"location"`
 	Categories   interface{}     `json:"categories"`
 	Distance     int             `json:"distance"`
-	
+
 	// Enhanced intelligence
 	BusinessIntelligence      BusinessIntelligence      `json:"business_intelligence"`
 	RealTimeContext          RealTimeContext           `json:"real_time_context"`
 	AccessibilityIntelligence AccessibilityIntelligence `json:"accessibility_intelligence"`
 	UnifiedRecommendations   UnifiedRecommendations    `json:"unified_recommendations"`
-	
+
+	// RawVenueDetails is the richer Foursquare payload the basic fields
+	// above were derived from, if the upstream request fetched it.
+	RawVenueDetails *RawVenueDetails `json:"raw_venue_details,omitempty"`
+
 	// Metadata
 	ProcessingTime time.Duration `json:"processing_time_ms"`
 	DataSources    []string      `json:"data_sources"`
@@ -136,5 +194,12 @@ type HealthResponse struct {
 		Foursquare   string `json:"foursquare"`
 		Intelligence string `json:"intelligence"`
 		Cache        string `json:"cache"`
+		Realtime     string `json:"realtime"`
+		// Providers reports per-backend health when PLACES_PROVIDER=multi
+		// fans searches out across several PlacesProvider backends - see
+		// MultiProvider.ProviderHealth. Omitted for a single-provider setup,
+		// where Foursquare above already covers it.
+		Providers map[string]string `json:"providers,omitempty"`
 	} `json:"services"`
+	Breakers map[string]string `json:"breakers,omitempty"`
 }
\ No newline at end of file
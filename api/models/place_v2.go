@@ -0,0 +1,145 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Location4sq mirrors FoursquarePlace.Location as a named type, so
+// PlaceIntelligenceV2 can expose it concretely instead of as interface{}.
+type Location4sq struct {
+	Address     string  `json:"address"`
+	Country     string  `json:"country"`
+	CrossStreet string  `json:"cross_street"`
+	Locality    string  `json:"locality"`
+	Postcode    string  `json:"postcode"`
+	Region      string  `json:"region"`
+	Latitude    float64 `json:"lat"`
+	Longitude   float64 `json:"lng"`
+}
+
+// Category4sq mirrors one entry of FoursquarePlace.Categories.
+type Category4sq struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+	Icon struct {
+		Prefix string `json:"prefix"`
+		Suffix string `json:"suffix"`
+	} `json:"icon"`
+}
+
+// Geocode is a single named point Foursquare v3's "geocodes" field reports
+// for a place (e.g. "main", "roof").
+type Geocode struct {
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lng"`
+}
+
+// Geocodes mirrors Foursquare v3's "geocodes" field. Left nil until the
+// client requests and unmarshals it - see FoursquarePlace's feature fields
+// for the same "nil means not fetched" convention.
+type Geocodes struct {
+	Main *Geocode `json:"main,omitempty"`
+	Roof *Geocode `json:"roof,omitempty"`
+}
+
+// PaginationCursors carries opaque forward/back cursors for
+// PlaceSearchResponseV2, so clients page through results without assuming
+// offset semantics.
+type PaginationCursors struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// PlaceIntelligenceV2 is PlaceIntelligence with Location/Categories typed
+// concretely instead of interface{}, plus the richer venue blocks already
+// modeled for RawVenueDetails, so consumers get a stable JSON schema
+// without re-type-asserting on every response. See
+// PlaceIntelligence.ToV2 for the converter from v1.
+type PlaceIntelligenceV2 struct {
+	FSQId      string        `json:"fsq_id"`
+	Name       string        `json:"name"`
+	Location   Location4sq   `json:"location"`
+	Categories []Category4sq `json:"categories"`
+	Distance   int           `json:"distance"`
+
+	Geocodes    *Geocodes         `json:"geocodes,omitempty"`
+	Chains      []PlaceChain      `json:"chains,omitempty"`
+	SocialMedia *PlaceSocialMedia `json:"social_media,omitempty"`
+	Stats       *PlaceStats       `json:"stats,omitempty"`
+
+	BusinessIntelligence      BusinessIntelligence      `json:"business_intelligence"`
+	RealTimeContext           RealTimeContext           `json:"real_time_context"`
+	AccessibilityIntelligence AccessibilityIntelligence `json:"accessibility_intelligence"`
+	UnifiedRecommendations    UnifiedRecommendations    `json:"unified_recommendations"`
+
+	ProcessingTime time.Duration `json:"processing_time_ms"`
+	DataSources    []string      `json:"data_sources"`
+	LastUpdated    time.Time     `json:"last_updated"`
+}
+
+// PlaceSearchResponseV2 is PlaceSearchResponse with V2 results and
+// cursor-based pagination instead of a flat list.
+type PlaceSearchResponseV2 struct {
+	Results []PlaceIntelligenceV2 `json:"results"`
+	Meta    struct {
+		Total          int           `json:"total"`
+		ProcessingTime time.Duration `json:"processing_time_ms"`
+		DataSources    []string      `json:"data_sources"`
+	} `json:"meta"`
+	Pagination PaginationCursors `json:"pagination"`
+}
+
+// ToV2 converts p into the strongly-typed V2 shape. Location and Categories
+// are re-encoded through JSON since PlaceIntelligence stores them as
+// interface{} - round-tripping through JSON normalizes whatever concrete
+// shape populated them (a FoursquarePlace's Location/Categories, or a
+// decoded intelligence-service response) into Location4sq/[]Category4sq
+// without a source-specific type switch.
+func (p PlaceIntelligence) ToV2() (PlaceIntelligenceV2, error) {
+	v2 := PlaceIntelligenceV2{
+		FSQId:    p.FSQId,
+		Name:     p.Name,
+		Distance: p.Distance,
+
+		BusinessIntelligence:      p.BusinessIntelligence,
+		RealTimeContext:           p.RealTimeContext,
+		AccessibilityIntelligence: p.AccessibilityIntelligence,
+		UnifiedRecommendations:    p.UnifiedRecommendations,
+
+		ProcessingTime: p.ProcessingTime,
+		DataSources:    p.DataSources,
+		LastUpdated:    p.LastUpdated,
+	}
+
+	if p.Location != nil {
+		if err := reencodeJSON(p.Location, &v2.Location); err != nil {
+			return PlaceIntelligenceV2{}, fmt.Errorf("failed to convert location to v2: %w", err)
+		}
+	}
+	if p.Categories != nil {
+		if err := reencodeJSON(p.Categories, &v2.Categories); err != nil {
+			return PlaceIntelligenceV2{}, fmt.Errorf("failed to convert categories to v2: %w", err)
+		}
+	}
+
+	if p.RawVenueDetails != nil {
+		v2.Chains = p.RawVenueDetails.Chains
+		v2.SocialMedia = p.RawVenueDetails.SocialMedia
+		v2.Stats = p.RawVenueDetails.Stats
+	}
+
+	return v2, nil
+}
+
+// reencodeJSON round-trips v through JSON into out - the simplest way to
+// convert an interface{} field of unknown concrete type into a known one
+// without a type switch over every source that could have populated it.
+func reencodeJSON(v interface{}, out interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
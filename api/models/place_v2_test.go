@@ -0,0 +1,127 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPlaceIntelligenceToV2RoundTrip proves ToV2 carries every field across
+// into the strongly-typed shape, including Location/Categories re-encoded
+// from interface{} and the RawVenueDetails-derived fields.
+func TestPlaceIntelligenceToV2RoundTrip(t *testing.T) {
+	p := PlaceIntelligence{
+		FSQId: "abc123",
+		Name:  "Test Cafe",
+		Location: map[string]interface{}{
+			"address":      "1 Main St",
+			"country":      "US",
+			"cross_street": "1st Ave",
+			"locality":     "Springfield",
+			"postcode":     "12345",
+			"region":       "IL",
+			"lat":          41.1,
+			"lng":          -88.2,
+		},
+		Categories: []map[string]interface{}{
+			{
+				"id":   13065,
+				"name": "Cafe",
+				"icon": map[string]interface{}{
+					"prefix": "https://ss3.4sqi.net/img/categories_v2/food/cafe_",
+					"suffix": ".png",
+				},
+			},
+		},
+		Distance: 42,
+		BusinessIntelligence: BusinessIntelligence{
+			PopularityScore: 0.9,
+			Specialties:     []string{"coffee"},
+		},
+		RealTimeContext: RealTimeContext{},
+		RawVenueDetails: &RawVenueDetails{
+			Chains:      []PlaceChain{{ID: "chain-1", Name: "Test Chain"}},
+			SocialMedia: &PlaceSocialMedia{Twitter: "testcafe"},
+			Stats:       &PlaceStats{TotalTips: 7},
+		},
+		ProcessingTime: 5 * time.Millisecond,
+		DataSources:    []string{"foursquare"},
+		LastUpdated:    time.Unix(1700000000, 0).UTC(),
+	}
+
+	v2, err := p.ToV2()
+	if err != nil {
+		t.Fatalf("ToV2() returned error: %v", err)
+	}
+
+	if v2.FSQId != p.FSQId {
+		t.Errorf("FSQId = %q, want %q", v2.FSQId, p.FSQId)
+	}
+	if v2.Name != p.Name {
+		t.Errorf("Name = %q, want %q", v2.Name, p.Name)
+	}
+	if v2.Distance != p.Distance {
+		t.Errorf("Distance = %d, want %d", v2.Distance, p.Distance)
+	}
+
+	wantLoc := Location4sq{
+		Address:     "1 Main St",
+		Country:     "US",
+		CrossStreet: "1st Ave",
+		Locality:    "Springfield",
+		Postcode:    "12345",
+		Region:      "IL",
+		Latitude:    41.1,
+		Longitude:   -88.2,
+	}
+	if v2.Location != wantLoc {
+		t.Errorf("Location = %+v, want %+v", v2.Location, wantLoc)
+	}
+
+	if len(v2.Categories) != 1 {
+		t.Fatalf("Categories length = %d, want 1", len(v2.Categories))
+	}
+	if v2.Categories[0].Id != 13065 || v2.Categories[0].Name != "Cafe" {
+		t.Errorf("Categories[0] = %+v, want Id=13065 Name=Cafe", v2.Categories[0])
+	}
+	if v2.Categories[0].Icon.Suffix != ".png" {
+		t.Errorf("Categories[0].Icon.Suffix = %q, want %q", v2.Categories[0].Icon.Suffix, ".png")
+	}
+
+	if v2.BusinessIntelligence.PopularityScore != p.BusinessIntelligence.PopularityScore {
+		t.Errorf("BusinessIntelligence not carried across: got %+v", v2.BusinessIntelligence)
+	}
+	if v2.ProcessingTime != p.ProcessingTime {
+		t.Errorf("ProcessingTime = %v, want %v", v2.ProcessingTime, p.ProcessingTime)
+	}
+	if !v2.LastUpdated.Equal(p.LastUpdated) {
+		t.Errorf("LastUpdated = %v, want %v", v2.LastUpdated, p.LastUpdated)
+	}
+
+	if len(v2.Chains) != 1 || v2.Chains[0].Name != "Test Chain" {
+		t.Errorf("Chains not carried across from RawVenueDetails: got %+v", v2.Chains)
+	}
+	if v2.SocialMedia == nil || v2.SocialMedia.Twitter != "testcafe" {
+		t.Errorf("SocialMedia not carried across from RawVenueDetails: got %+v", v2.SocialMedia)
+	}
+	if v2.Stats == nil || v2.Stats.TotalTips != 7 {
+		t.Errorf("Stats not carried across from RawVenueDetails: got %+v", v2.Stats)
+	}
+}
+
+// TestPlaceIntelligenceToV2NilLocationCategories proves ToV2 doesn't choke
+// when Location/Categories were never populated (e.g. a basic intelligence
+// response that skipped the raw venue fetch).
+func TestPlaceIntelligenceToV2NilLocationCategories(t *testing.T) {
+	p := PlaceIntelligence{FSQId: "no-location"}
+
+	v2, err := p.ToV2()
+	if err != nil {
+		t.Fatalf("ToV2() returned error: %v", err)
+	}
+	if v2.Location != (Location4sq{}) {
+		t.Errorf("Location = %+v, want zero value", v2.Location)
+	}
+	if v2.Categories != nil {
+		t.Errorf("Categories = %+v, want nil", v2.Categories)
+	}
+}
@@ -0,0 +1,152 @@
+package models
+
+// PlaceHours mirrors Foursquare v3's "hours" field.
+type PlaceHours struct {
+	Display        string `json:"display,omitempty"`
+	IsLocalHoliday bool   `json:"is_local_holiday,omitempty"`
+	OpenNow        bool   `json:"open_now,omitempty"`
+	Regular        []struct {
+		Close string `json:"close"`
+		Day   int    `json:"day"`
+		Open  string `json:"open"`
+	} `json:"regular,omitempty"`
+}
+
+// PopularHoursBlock is one entry in Foursquare v3's "hours_popular" field -
+// the hours the venue is actually busiest, as distinct from its posted hours.
+type PopularHoursBlock struct {
+	Close string `json:"close"`
+	Day   int    `json:"day"`
+	Open  string `json:"open"`
+}
+
+// PlaceStats mirrors Foursquare v3's "stats" field.
+type PlaceStats struct {
+	TotalPhotos  int `json:"total_photos,omitempty"`
+	TotalRatings int `json:"total_ratings,omitempty"`
+	TotalTips    int `json:"total_tips,omitempty"`
+}
+
+// PlacePhoto is one entry in Foursquare v3's "photos" field.
+type PlacePhoto struct {
+	ID        string      `json:"id"`
+	CreatedAt *CustomTime `json:"created_at,omitempty"`
+	Prefix    string      `json:"prefix"`
+	Suffix    string      `json:"suffix"`
+	Width     int         `json:"width"`
+	Height    int         `json:"height"`
+}
+
+// PlaceTip is one entry in Foursquare v3's "tips" field.
+type PlaceTip struct {
+	ID        string      `json:"id"`
+	CreatedAt *CustomTime `json:"created_at,omitempty"`
+	Text      string      `json:"text"`
+	LikeCount int         `json:"like_count,omitempty"`
+}
+
+// PlaceSocialMedia mirrors Foursquare v3's "social_media" field.
+type PlaceSocialMedia struct {
+	FacebookID string `json:"facebook_id,omitempty"`
+	Instagram  string `json:"instagram,omitempty"`
+	Twitter    string `json:"twitter,omitempty"`
+}
+
+// PlaceChain is one entry in Foursquare v3's "chains" field.
+type PlaceChain struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// PlaceFeatures mirrors Foursquare v3's "features" attribute tree: payment
+// methods, food & drink options, services, amenities, and vibe/attribute
+// tags. Every leaf is a *bool so "not reported by Foursquare" (nil) stays
+// distinguishable from "reported false".
+type PlaceFeatures struct {
+	Payment      *PaymentFeatures      `json:"payment,omitempty"`
+	FoodAndDrink *FoodAndDrinkFeatures `json:"food_and_drink,omitempty"`
+	Services     *ServicesFeatures     `json:"services,omitempty"`
+	Amenities    *AmenitiesFeatures    `json:"amenities,omitempty"`
+	Attributes   *AttributesFeatures   `json:"attributes,omitempty"`
+}
+
+type PaymentFeatures struct {
+	CreditCards   *CreditCardFeatures   `json:"credit_cards,omitempty"`
+	DigitalWallet *DigitalWalletFeatures `json:"digital_wallet,omitempty"`
+}
+
+type CreditCardFeatures struct {
+	AcceptsCreditCards *bool `json:"accepts_credit_cards,omitempty"`
+	Visa               *bool `json:"visa,omitempty"`
+	Mastercard         *bool `json:"mastercard,omitempty"`
+	AmericanExpress    *bool `json:"american_express,omitempty"`
+	DiscoverCard       *bool `json:"discover_card,omitempty"`
+}
+
+type DigitalWalletFeatures struct {
+	AcceptsNFC *bool `json:"accepts_nfc,omitempty"`
+}
+
+type FoodAndDrinkFeatures struct {
+	Alcohol *AlcoholFeatures `json:"alcohol,omitempty"`
+	Meals   *MealsFeatures   `json:"meals,omitempty"`
+	Dietary *DietaryFeatures `json:"dietary_restrictions,omitempty"`
+}
+
+type AlcoholFeatures struct {
+	BeerAndWine *bool `json:"beer_and_wine,omitempty"`
+	FullBar     *bool `json:"full_bar,omitempty"`
+	BYO         *bool `json:"byo,omitempty"`
+}
+
+type MealsFeatures struct {
+	Breakfast *bool `json:"breakfast,omitempty"`
+	Brunch    *bool `json:"brunch,omitempty"`
+	Lunch     *bool `json:"lunch,omitempty"`
+	Dinner    *bool `json:"dinner,omitempty"`
+	Dessert   *bool `json:"dessert,omitempty"`
+}
+
+type DietaryFeatures struct {
+	Vegetarian *bool `json:"vegetarian_diet,omitempty"`
+	Vegan      *bool `json:"vegan_diet,omitempty"`
+	GlutenFree *bool `json:"gluten_free_diet,omitempty"`
+}
+
+type ServicesFeatures struct {
+	Delivery     *bool           `json:"delivery,omitempty"`
+	Takeout      *bool           `json:"takeout,omitempty"`
+	DriveThrough *bool           `json:"drive_through,omitempty"`
+	DineIn       *DineInFeatures `json:"dine_in,omitempty"`
+}
+
+type DineInFeatures struct {
+	Reservations       *bool `json:"reservations,omitempty"`
+	OnlineReservations *bool `json:"online_reservations,omitempty"`
+}
+
+type AmenitiesFeatures struct {
+	Wifi     *string          `json:"wifi,omitempty"` // "free", "paid", "none"
+	Restroom *bool            `json:"restroom,omitempty"`
+	Parking  *ParkingFeatures `json:"parking,omitempty"`
+	Outdoor  *bool            `json:"outdoor_seating,omitempty"`
+}
+
+type ParkingFeatures struct {
+	ParkingLot    *bool `json:"parking,omitempty"`
+	StreetParking *bool `json:"street_parking,omitempty"`
+	ValetParking  *bool `json:"valet_parking,omitempty"`
+	PublicLot     *bool `json:"public_lot,omitempty"`
+	ParkingGarage *bool `json:"parking_garage,omitempty"`
+}
+
+type AttributesFeatures struct {
+	GoodFor *GoodForFeatures `json:"good_for,omitempty"`
+	Crowd   []string         `json:"crowd,omitempty"`
+	Music   []string         `json:"music,omitempty"`
+}
+
+type GoodForFeatures struct {
+	Groups    *bool `json:"groups,omitempty"`
+	DateNight *bool `json:"date_night,omitempty"`
+}
@@ -0,0 +1,12 @@
+// Package intelligencev1 holds the protobuf/gRPC contract for the
+// intelligence service and the stubs generated from it.
+//
+// Run `go generate ./...` (requires protoc, protoc-gen-go and
+// protoc-gen-go-grpc on PATH) to regenerate intelligence.pb.go and
+// intelligence_grpc.pb.go after editing intelligence.proto. Generated files
+// are not checked in; CI regenerates them before building.
+package intelligencev1
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative \
+//go:generate   --go-grpc_out=. --go-grpc_opt=paths=source_relative \
+//go:generate   intelligence.proto
@@ -0,0 +1,309 @@
+// Package realtime keeps RealTimeContext fresh for places callers care
+// about, instead of recomputing it from scratch on every request. Modeled
+// on classic space-status polling: each tracked place moves between a
+// small set of states depending on whether its last fetch succeeded.
+package realtime
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"placeintel-pro/api/models"
+	"placeintel-pro/api/services"
+)
+
+// State describes how much a tracked place's RealTimeContext can be trusted.
+type State string
+
+const (
+	// StateFresh means the last poll succeeded and the context reflects
+	// a recent fetch.
+	StateFresh State = "fresh"
+	// StateOutdated means consecutive polls have failed, but a previous
+	// successful fetch is still being served rather than dropped.
+	StateOutdated State = "outdated"
+	// StateUnknown means the place has never been successfully polled.
+	StateUnknown State = "unknown"
+	// StateClosed means the upstream details reported the place as
+	// permanently closed; polling continues at a relaxed interval in case
+	// that changes, but the place won't be reported as Fresh/Outdated.
+	StateClosed State = "closed"
+)
+
+const (
+	// DefaultInterval is the poll interval used for places subscribed
+	// without an explicit interval.
+	DefaultInterval = 5 * time.Minute
+	// maxBackoff caps how far a failing place's poll interval can stretch.
+	maxBackoff = 30 * time.Minute
+	// outdatedAfterFailures is how many consecutive failed polls it takes
+	// before a place moves from Fresh to Outdated.
+	outdatedAfterFailures = 2
+)
+
+// record tracks one subscribed place's polling state. fsqID, interval, and
+// cancel are set once at creation and never mutated afterwards, so they're
+// safe to read without a lock; state, consecutiveFails, and context are
+// written by the background poll loop and read by Health, so they're
+// guarded by their own mutex, independent of Poller.mu (which only protects
+// the records map itself).
+type record struct {
+	fsqID    string
+	interval time.Duration
+	cancel   context.CancelFunc
+
+	mu               sync.Mutex
+	state            State
+	consecutiveFails int
+	context          models.RealTimeContext
+}
+
+// recordFailure marks one failed poll and returns the resulting consecutive
+// failure count and state, moving to StateOutdated once that count reaches
+// outdatedAfterFailures.
+func (r *record) recordFailure() (consecutiveFails int, state State) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.consecutiveFails++
+	if r.consecutiveFails >= outdatedAfterFailures {
+		r.state = StateOutdated
+	}
+	return r.consecutiveFails, r.state
+}
+
+// recordSuccess records a successful poll's resulting context, resetting the
+// failure count and moving to StateClosed or StateFresh depending on
+// whether the place reported itself closed.
+func (r *record) recordSuccess(rtc models.RealTimeContext) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.consecutiveFails = 0
+	r.context = rtc
+	if rtc.CurrentStatus == "closed" {
+		r.state = StateClosed
+	} else {
+		r.state = StateFresh
+	}
+}
+
+// snapshot returns the record's current state and consecutive-failure count.
+func (r *record) snapshot() (state State, consecutiveFails int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.state, r.consecutiveFails
+}
+
+// Poller runs one goroutine per subscribed place that periodically
+// re-fetches Foursquare details and refreshes the place's RealTimeContext,
+// both in memory and in the shared cache.
+type Poller struct {
+	placesProvider services.PlacesProvider
+	cacheService   *services.CacheService
+
+	mu      sync.Mutex
+	records map[string]*record
+}
+
+// NewPoller creates a Poller. It starts no goroutines until places are
+// subscribed via SubscribePlace.
+func NewPoller(placesProvider services.PlacesProvider, cacheService *services.CacheService) *Poller {
+	return &Poller{
+		placesProvider: placesProvider,
+		cacheService:   cacheService,
+		records:        make(map[string]*record),
+	}
+}
+
+// SubscribePlace starts (or restarts, with the new interval) background
+// polling for fsqID. Safe to call repeatedly, e.g. once per lookup of the
+// same place - later calls simply replace the existing subscription.
+func (p *Poller) SubscribePlace(fsqID string, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if existing, ok := p.records[fsqID]; ok {
+		existing.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rec := &record{
+		fsqID:    fsqID,
+		interval: interval,
+		cancel:   cancel,
+		state:    StateUnknown,
+	}
+	p.records[fsqID] = rec
+
+	go p.run(ctx, rec)
+}
+
+// UnsubscribePlace stops background polling for fsqID, if subscribed.
+func (p *Poller) UnsubscribePlace(fsqID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	rec, ok := p.records[fsqID]
+	if !ok {
+		return
+	}
+	rec.cancel()
+	delete(p.records, fsqID)
+}
+
+// Stop cancels polling for every subscribed place, for use during graceful
+// shutdown.
+func (p *Poller) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for fsqID, rec := range p.records {
+		rec.cancel()
+		delete(p.records, fsqID)
+	}
+}
+
+// Health summarizes poller state for HealthResponse.Services.Realtime:
+// "unknown" when nothing is tracked yet, "degraded" when any tracked place
+// has fallen Outdated, otherwise "ok".
+func (p *Poller) Health() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.records) == 0 {
+		return "unknown"
+	}
+	for _, rec := range p.records {
+		if state, _ := rec.snapshot(); state == StateOutdated {
+			return "degraded"
+		}
+	}
+	return "ok"
+}
+
+// run polls rec on its interval until ctx is canceled (via UnsubscribePlace
+// or Stop), backing off exponentially while polls keep failing.
+func (p *Poller) run(ctx context.Context, rec *record) {
+	for {
+		p.poll(ctx, rec)
+
+		_, consecutiveFails := rec.snapshot()
+		sleep := backoffDuration(rec.interval, consecutiveFails)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(sleep):
+		}
+	}
+}
+
+// poll re-fetches details for rec.fsqID, derives a fresh RealTimeContext,
+// and writes it to the cache. A fetch error marks the place Outdated after
+// outdatedAfterFailures consecutive failures rather than dropping the last
+// known-good context.
+func (p *Poller) poll(ctx context.Context, rec *record) {
+	details, err := p.placesProvider.GetPlaceDetails(ctx, rec.fsqID)
+	if err != nil {
+		consecutiveFails, _ := rec.recordFailure()
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"fsq_id":            rec.fsqID,
+			"consecutive_fails": consecutiveFails,
+		}).Warn("Realtime poll failed")
+		return
+	}
+
+	rtc := realTimeContextFromDetails(details)
+	rec.recordSuccess(rtc)
+
+	if err := p.cacheService.CacheRealTimeContext(ctx, rec.fsqID, rtc); err != nil {
+		logrus.WithError(err).WithField("fsq_id", rec.fsqID).Warn("Failed to cache realtime context")
+	}
+}
+
+// backoffDuration scales interval up with consecutive failures, doubling
+// per failure and capping at maxBackoff. With no failures it's just interval.
+func backoffDuration(interval time.Duration, consecutiveFails int) time.Duration {
+	if consecutiveFails == 0 {
+		return interval
+	}
+
+	backoff := interval
+	for i := 0; i < consecutiveFails; i++ {
+		backoff *= 2
+		if backoff >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return backoff
+}
+
+// realTimeContextFromDetails derives a RealTimeContext from a fresh
+// Foursquare details fetch, the same signals GetPlaceIntelligenceByID
+// already pulls hours/popularity from.
+func realTimeContextFromDetails(details *services.FoursquarePlaceDetails) models.RealTimeContext {
+	status := confidenceStatus(details)
+
+	return models.RealTimeContext{
+		CurrentStatus:     status,
+		CrowdLevel:        crowdLevelFromPopularity(details.Popularity),
+		BestVisitTimes:    []string{},
+		LiveEvents:        []string{},
+		EstimatedWaitTime: waitTimeFromPopularity(details.Popularity),
+		LastUpdated:       time.Now(),
+		ConfidenceScore:   confidenceForStatus(status),
+	}
+}
+
+func confidenceStatus(details *services.FoursquarePlaceDetails) string {
+	if details.ClosedBucket == "VeryLikelyClosed" || details.ClosedBucket == "LikelyClosed" {
+		return "closed"
+	}
+	if details.Hours.OpenNow {
+		return "open"
+	}
+	return "closed"
+}
+
+func confidenceForStatus(status string) float64 {
+	switch status {
+	case "open", "closed":
+		return 0.8
+	default:
+		return 0.3
+	}
+}
+
+// crowdLevelFromPopularity buckets Foursquare's 0-1 popularity score into a
+// coarse human label, the same tiers createBasicPlaceIntelligence uses for
+// atmosphere.
+func crowdLevelFromPopularity(popularity float64) string {
+	switch {
+	case popularity >= 0.75:
+		return "high"
+	case popularity >= 0.4:
+		return "moderate"
+	case popularity > 0:
+		return "low"
+	default:
+		return "unknown"
+	}
+}
+
+func waitTimeFromPopularity(popularity float64) string {
+	switch {
+	case popularity >= 0.75:
+		return "15-30 minutes"
+	case popularity >= 0.4:
+		return "5-15 minutes"
+	default:
+		return "no wait"
+	}
+}
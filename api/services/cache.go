@@ -9,15 +9,28 @@ import (
 	"github.com/go-redis/redis/v8"
 	"github.com/sirupsen/logrus"
 	"placeintel-pro/api/models"
+	"placeintel-pro/api/services/resilience"
 )
 
-// CacheService handles Redis caching operations
+// invalidateChannel is the Redis Pub/Sub channel every instance subscribes to
+// so a cache write or explicit invalidation on one instance evicts the stale
+// copy every other instance is holding in its local LRU cache.
+const invalidateChannel = "placeintel:invalidate"
+
+// CacheService handles Redis caching operations, fronted by a small
+// in-process LRU so repeat reads for the same hot key don't round-trip to
+// Redis. Every method takes the caller's context.Context so a client
+// disconnect or per-request deadline cancels the underlying Redis call
+// instead of it outliving the request.
 type CacheService struct {
-	client *redis.Client
-	ctx    context.Context
+	client    *redis.Client
+	breaker   *resilience.Breaker
+	local     *localLRUCache
+	subCancel context.CancelFunc
 }
 
-// NewCacheService creates a new cache service instance
+// NewCacheService creates a new cache service instance and starts its
+// background subscriber for cross-instance cache invalidation.
 func NewCacheService(host, port, password string) *CacheService {
 	rdb := redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%s", host, port),
@@ -25,41 +38,121 @@ func NewCacheService(host, port, password string) *CacheService {
 		DB:       0, // Default DB
 	})
 
-	return &CacheService{
-		client: rdb,
-		ctx:    context.Background(),
+	cs := &CacheService{
+		client:  rdb,
+		breaker: resilience.NewBreaker("redis", resilience.BreakerConfigFromEnv("REDIS")),
+		local:   newLocalLRUCache(defaultLocalCacheCapacity),
 	}
+
+	// The subscriber loop is the one piece of this service with its own
+	// lifetime independent of any single request, so it gets its own
+	// internally-owned context rather than one threaded in from a caller.
+	// Close() cancels it.
+	subCtx, cancel := context.WithCancel(context.Background())
+	cs.subCancel = cancel
+	go cs.subscribeInvalidations(subCtx)
+
+	return cs
 }
 
 // Cache key prefixes
 const (
-	PlaceSearchPrefix     = "search:"
+	PlaceSearchPrefix       = "search:"
 	PlaceIntelligencePrefix = "intel:"
-	PlaceDetailsPrefix    = "details:"
-	PopularPlacesPrefix   = "popular:"
-	TrendsPrefix         = "trends:"
+	PlaceDetailsPrefix      = "details:"
+	PopularPlacesPrefix     = "popular:"
+	TrendsPrefix            = "trends:"
+	RealtimeContextPrefix   = "realtime:"
 )
 
 // Cache TTL durations
 const (
-	SearchCacheTTL      = 5 * time.Minute   // Search results change frequently
-	IntelligenceCacheTTL = 15 * time.Minute // Intelligence data is more stable
-	DetailsCacheTTL     = 30 * time.Minute  // Place details change less frequently
-	PopularCacheTTL     = 1 * time.Hour     // Popular places aggregated data
-	TrendsCacheTTL      = 2 * time.Hour     // Trends data
+	SearchCacheTTL        = 5 * time.Minute  // Search results change frequently
+	IntelligenceCacheTTL  = 15 * time.Minute // Intelligence data is more stable
+	DetailsCacheTTL       = 30 * time.Minute // Place details change less frequently
+	PopularCacheTTL       = 1 * time.Hour    // Popular places aggregated data
+	TrendsCacheTTL        = 2 * time.Hour    // Trends data
+	RealtimeContextCacheTTL = 10 * time.Minute // Slightly longer than the realtime poller's default interval, so a brief poller outage doesn't expire the last known value
 )
 
+// subscribeInvalidations evicts local LRU entries as invalidation events
+// arrive from any instance (including this one) over invalidateChannel, until
+// ctx is cancelled by Close.
+func (cs *CacheService) subscribeInvalidations(ctx context.Context) {
+	pubsub := cs.client.Subscribe(ctx, invalidateChannel)
+	defer pubsub.Close()
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			cs.local.DeleteMatching(msg.Payload)
+		}
+	}
+}
+
+// publishInvalidation broadcasts a cache key (or glob pattern) on
+// invalidateChannel so every instance, including this one, evicts it from
+// local memory. Failures are logged and swallowed - Redis TTLs still bound
+// staleness even if the broadcast is dropped.
+func (cs *CacheService) publishInvalidation(ctx context.Context, pattern string) {
+	if err := cs.client.Publish(ctx, invalidateChannel, pattern).Err(); err != nil {
+		logrus.WithError(err).WithField("pattern", pattern).Warn("Failed to publish cache invalidation")
+	}
+}
+
+// getCached checks the local LRU cache before falling back to Redis,
+// populating the local cache on a Redis hit so repeat reads for the same key
+// from this instance don't round-trip again. ok is false on either a local or
+// a Redis miss.
+func (cs *CacheService) getCached(ctx context.Context, cacheKey string) (data []byte, ok bool, err error) {
+	if raw, hit := cs.local.Get(cacheKey); hit {
+		return raw, true, nil
+	}
+
+	raw, err := cs.client.Get(ctx, cacheKey).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	cs.local.Set(cacheKey, []byte(raw))
+	return []byte(raw), true, nil
+}
+
+// setCached writes through to Redis with ttl, then refreshes the local LRU
+// cache and broadcasts an invalidation so other instances drop any stale
+// local copy of cacheKey rather than waiting out their own copy's TTL. This
+// instance's own local copy is left as the one just written; receiving its
+// own broadcast just costs it one extra Redis round trip on the next read,
+// which is a fine trade for keeping the eviction path the same on every
+// instance.
+func (cs *CacheService) setCached(ctx context.Context, cacheKey string, data []byte, ttl time.Duration) error {
+	if err := cs.client.Set(ctx, cacheKey, data, ttl).Err(); err != nil {
+		return err
+	}
+	cs.local.Set(cacheKey, data)
+	cs.publishInvalidation(ctx, cacheKey)
+	return nil
+}
+
 // CacheSearchResults caches place search results
-func (cs *CacheService) CacheSearchResults(key string, results []models.PlaceIntelligence) error {
+func (cs *CacheService) CacheSearchResults(ctx context.Context, key string, results []models.PlaceIntelligence) error {
 	cacheKey := PlaceSearchPrefix + key
-	
+
 	data, err := json.Marshal(results)
 	if err != nil {
 		return fmt.Errorf("failed to marshal search results: %w", err)
 	}
 
-	err = cs.client.Set(cs.ctx, cacheKey, data, SearchCacheTTL).Err()
-	if err != nil {
+	if err := cs.setCached(ctx, cacheKey, data, SearchCacheTTL); err != nil {
 		logrus.WithError(err).WithField("key", cacheKey).Error("Failed to cache search results")
 		return err
 	}
@@ -74,19 +167,19 @@ func (cs *CacheService) CacheSearchResults(key string, results []models.PlaceInt
 }
 
 // GetCachedSearchResults retrieves cached search results
-func (cs *CacheService) GetCachedSearchResults(key string) ([]models.PlaceIntelligence, error) {
+func (cs *CacheService) GetCachedSearchResults(ctx context.Context, key string) ([]models.PlaceIntelligence, error) {
 	cacheKey := PlaceSearchPrefix + key
-	
-	data, err := cs.client.Get(cs.ctx, cacheKey).Result()
+
+	data, ok, err := cs.getCached(ctx, cacheKey)
 	if err != nil {
-		if err == redis.Nil {
-			return nil, nil // Cache miss
-		}
 		return nil, fmt.Errorf("failed to get cached search results: %w", err)
 	}
+	if !ok {
+		return nil, nil // Cache miss
+	}
 
 	var results []models.PlaceIntelligence
-	if err := json.Unmarshal([]byte(data), &results); err != nil {
+	if err := json.Unmarshal(data, &results); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal cached search results: %w", err)
 	}
 
@@ -99,16 +192,15 @@ func (cs *CacheService) GetCachedSearchResults(key string) ([]models.PlaceIntell
 }
 
 // CachePlaceIntelligence caches individual place intelligence
-func (cs *CacheService) CachePlaceIntelligence(placeID string, intelligence *models.PlaceIntelligence) error {
+func (cs *CacheService) CachePlaceIntelligence(ctx context.Context, placeID string, intelligence *models.PlaceIntelligence) error {
 	cacheKey := PlaceIntelligencePrefix + placeID
-	
+
 	data, err := json.Marshal(intelligence)
 	if err != nil {
 		return fmt.Errorf("failed to marshal place intelligence: %w", err)
 	}
 
-	err = cs.client.Set(cs.ctx, cacheKey, data, IntelligenceCacheTTL).Err()
-	if err != nil {
+	if err := cs.setCached(ctx, cacheKey, data, IntelligenceCacheTTL); err != nil {
 		logrus.WithError(err).WithField("key", cacheKey).Error("Failed to cache place intelligence")
 		return err
 	}
@@ -123,19 +215,19 @@ func (cs *CacheService) CachePlaceIntelligence(placeID string, intelligence *mod
 }
 
 // GetCachedPlaceIntelligence retrieves cached place intelligence
-func (cs *CacheService) GetCachedPlaceIntelligence(placeID string) (*models.PlaceIntelligence, error) {
+func (cs *CacheService) GetCachedPlaceIntelligence(ctx context.Context, placeID string) (*models.PlaceIntelligence, error) {
 	cacheKey := PlaceIntelligencePrefix + placeID
-	
-	data, err := cs.client.Get(cs.ctx, cacheKey).Result()
+
+	data, ok, err := cs.getCached(ctx, cacheKey)
 	if err != nil {
-		if err == redis.Nil {
-			return nil, nil // Cache miss
-		}
 		return nil, fmt.Errorf("failed to get cached place intelligence: %w", err)
 	}
+	if !ok {
+		return nil, nil // Cache miss
+	}
 
 	var intelligence models.PlaceIntelligence
-	if err := json.Unmarshal([]byte(data), &intelligence); err != nil {
+	if err := json.Unmarshal(data, &intelligence); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal cached place intelligence: %w", err)
 	}
 
@@ -148,16 +240,15 @@ func (cs *CacheService) GetCachedPlaceIntelligence(placeID string) (*models.Plac
 }
 
 // CachePlaceDetails caches Foursquare place details
-func (cs *CacheService) CachePlaceDetails(placeID string, details interface{}) error {
+func (cs *CacheService) CachePlaceDetails(ctx context.Context, placeID string, details interface{}) error {
 	cacheKey := PlaceDetailsPrefix + placeID
-	
+
 	data, err := json.Marshal(details)
 	if err != nil {
 		return fmt.Errorf("failed to marshal place details: %w", err)
 	}
 
-	err = cs.client.Set(cs.ctx, cacheKey, data, DetailsCacheTTL).Err()
-	if err != nil {
+	if err := cs.setCached(ctx, cacheKey, data, DetailsCacheTTL); err != nil {
 		logrus.WithError(err).WithField("key", cacheKey).Error("Failed to cache place details")
 		return err
 	}
@@ -172,19 +263,19 @@ func (cs *CacheService) CachePlaceDetails(placeID string, details interface{}) e
 }
 
 // GetCachedPlaceDetails retrieves cached place details
-func (cs *CacheService) GetCachedPlaceDetails(placeID string) (interface{}, error) {
+func (cs *CacheService) GetCachedPlaceDetails(ctx context.Context, placeID string) (interface{}, error) {
 	cacheKey := PlaceDetailsPrefix + placeID
-	
-	data, err := cs.client.Get(cs.ctx, cacheKey).Result()
+
+	data, ok, err := cs.getCached(ctx, cacheKey)
 	if err != nil {
-		if err == redis.Nil {
-			return nil, nil // Cache miss
-		}
 		return nil, fmt.Errorf("failed to get cached place details: %w", err)
 	}
+	if !ok {
+		return nil, nil // Cache miss
+	}
 
 	var details interface{}
-	if err := json.Unmarshal([]byte(data), &details); err != nil {
+	if err := json.Unmarshal(data, &details); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal cached place details: %w", err)
 	}
 
@@ -197,16 +288,15 @@ func (cs *CacheService) GetCachedPlaceDetails(placeID string) (interface{}, erro
 }
 
 // CachePopularPlaces caches popular places data
-func (cs *CacheService) CachePopularPlaces(location string, places []models.PlaceIntelligence) error {
+func (cs *CacheService) CachePopularPlaces(ctx context.Context, location string, places []models.PlaceIntelligence) error {
 	cacheKey := PopularPlacesPrefix + location
-	
+
 	data, err := json.Marshal(places)
 	if err != nil {
 		return fmt.Errorf("failed to marshal popular places: %w", err)
 	}
 
-	err = cs.client.Set(cs.ctx, cacheKey, data, PopularCacheTTL).Err()
-	if err != nil {
+	if err := cs.setCached(ctx, cacheKey, data, PopularCacheTTL); err != nil {
 		logrus.WithError(err).WithField("key", cacheKey).Error("Failed to cache popular places")
 		return err
 	}
@@ -222,19 +312,19 @@ func (cs *CacheService) CachePopularPlaces(location string, places []models.Plac
 }
 
 // GetCachedPopularPlaces retrieves cached popular places
-func (cs *CacheService) GetCachedPopularPlaces(location string) ([]models.PlaceIntelligence, error) {
+func (cs *CacheService) GetCachedPopularPlaces(ctx context.Context, location string) ([]models.PlaceIntelligence, error) {
 	cacheKey := PopularPlacesPrefix + location
-	
-	data, err := cs.client.Get(cs.ctx, cacheKey).Result()
+
+	data, ok, err := cs.getCached(ctx, cacheKey)
 	if err != nil {
-		if err == redis.Nil {
-			return nil, nil // Cache miss
-		}
 		return nil, fmt.Errorf("failed to get cached popular places: %w", err)
 	}
+	if !ok {
+		return nil, nil // Cache miss
+	}
 
 	var places []models.PlaceIntelligence
-	if err := json.Unmarshal([]byte(data), &places); err != nil {
+	if err := json.Unmarshal(data, &places); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal cached popular places: %w", err)
 	}
 
@@ -247,18 +337,68 @@ func (cs *CacheService) GetCachedPopularPlaces(location string) ([]models.PlaceI
 	return places, nil
 }
 
-// InvalidateCache removes cached data for a specific key pattern
-func (cs *CacheService) InvalidateCache(pattern string) error {
-	keys, err := cs.client.Keys(cs.ctx, pattern).Result()
+// CacheRealTimeContext caches the realtime package's latest polled context
+// for a place, so handlers can serve it without a live Foursquare call.
+func (cs *CacheService) CacheRealTimeContext(ctx context.Context, fsqID string, rtc models.RealTimeContext) error {
+	cacheKey := RealtimeContextPrefix + fsqID
+
+	data, err := json.Marshal(rtc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal realtime context: %w", err)
+	}
+
+	if err := cs.setCached(ctx, cacheKey, data, RealtimeContextCacheTTL); err != nil {
+		logrus.WithError(err).WithField("key", cacheKey).Error("Failed to cache realtime context")
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"key":    cacheKey,
+		"fsq_id": fsqID,
+		"ttl":    RealtimeContextCacheTTL,
+	}).Debug("Cached realtime context")
+
+	return nil
+}
+
+// GetCachedRealTimeContext retrieves the realtime package's last polled
+// context for a place, or nil on a cache miss.
+func (cs *CacheService) GetCachedRealTimeContext(ctx context.Context, fsqID string) (*models.RealTimeContext, error) {
+	cacheKey := RealtimeContextPrefix + fsqID
+
+	data, ok, err := cs.getCached(ctx, cacheKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached realtime context: %w", err)
+	}
+	if !ok {
+		return nil, nil // Cache miss
+	}
+
+	var rtc models.RealTimeContext
+	if err := json.Unmarshal(data, &rtc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cached realtime context: %w", err)
+	}
+
+	return &rtc, nil
+}
+
+// InvalidateCache removes cached data for a specific key pattern, and
+// broadcasts the pattern on invalidateChannel so every instance evicts any
+// matching entries from its local LRU cache too.
+func (cs *CacheService) InvalidateCache(ctx context.Context, pattern string) error {
+	keys, err := cs.client.Keys(ctx, pattern).Result()
 	if err != nil {
 		return fmt.Errorf("failed to get keys for pattern %s: %w", pattern, err)
 	}
 
+	cs.local.DeleteMatching(pattern)
+	cs.publishInvalidation(ctx, pattern)
+
 	if len(keys) == 0 {
 		return nil // No keys to delete
 	}
 
-	err = cs.client.Del(cs.ctx, keys...).Err()
+	err = cs.client.Del(ctx, keys...).Err()
 	if err != nil {
 		return fmt.Errorf("failed to delete keys: %w", err)
 	}
@@ -272,17 +412,17 @@ func (cs *CacheService) InvalidateCache(pattern string) error {
 }
 
 // GetCacheStats returns cache statistics
-func (cs *CacheService) GetCacheStats() (map[string]interface{}, error) {
-	info, err := cs.client.Info(cs.ctx, "stats").Result()
+func (cs *CacheService) GetCacheStats(ctx context.Context) (map[string]interface{}, error) {
+	info, err := cs.client.Info(ctx, "stats").Result()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cache stats: %w", err)
 	}
 
 	// Get key counts for different prefixes
-	searchKeys, _ := cs.client.Keys(cs.ctx, PlaceSearchPrefix+"*").Result()
-	intelKeys, _ := cs.client.Keys(cs.ctx, PlaceIntelligencePrefix+"*").Result()
-	detailKeys, _ := cs.client.Keys(cs.ctx, PlaceDetailsPrefix+"*").Result()
-	popularKeys, _ := cs.client.Keys(cs.ctx, PopularPlacesPrefix+"*").Result()
+	searchKeys, _ := cs.client.Keys(ctx, PlaceSearchPrefix+"*").Result()
+	intelKeys, _ := cs.client.Keys(ctx, PlaceIntelligencePrefix+"*").Result()
+	detailKeys, _ := cs.client.Keys(ctx, PlaceDetailsPrefix+"*").Result()
+	popularKeys, _ := cs.client.Keys(ctx, PopularPlacesPrefix+"*").Result()
 
 	stats := map[string]interface{}{
 		"redis_info":        info,
@@ -297,15 +437,26 @@ func (cs *CacheService) GetCacheStats() (map[string]interface{}, error) {
 }
 
 // HealthCheck verifies Redis connectivity
-func (cs *CacheService) HealthCheck() error {
-	_, err := cs.client.Ping(cs.ctx).Result()
+func (cs *CacheService) HealthCheck(ctx context.Context) error {
+	err := cs.breaker.Execute(func() error {
+		_, err := cs.client.Ping(ctx).Result()
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("redis health check failed: %w", err)
 	}
 	return nil
 }
 
-// Close closes the Redis connection
+// Close stops the invalidation subscriber and closes the Redis connection
 func (cs *CacheService) Close() error {
+	cs.subCancel()
 	return cs.client.Close()
-}
\ No newline at end of file
+}
+
+// Client exposes the underlying Redis client so other packages (e.g. the
+// distributed rate limiter) can share the same connection pool instead of
+// opening a second one.
+func (cs *CacheService) Client() *redis.Client {
+	return cs.client
+}
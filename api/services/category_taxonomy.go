@@ -0,0 +1,89 @@
+package services
+
+import "strings"
+
+// Shared internal category taxonomy. Every provider translates its native
+// categories into this set so the intelligence pipeline and downstream
+// consumers don't need to know which backend a place came from.
+const (
+	CategoryRestaurant = "restaurant"
+	CategoryCafe       = "cafe"
+	CategoryBar        = "bar"
+	CategoryShop       = "shop"
+	CategoryGrocery    = "grocery"
+	CategoryFitness    = "fitness"
+	CategoryPark       = "park"
+	CategoryHotel      = "hotel"
+	CategoryEntertainment = "entertainment"
+	CategoryService    = "service"
+	CategoryOther      = "other"
+)
+
+// foursquareCategoryMap maps common Foursquare top-level category names to
+// the shared taxonomy. Foursquare's full taxonomy is much deeper than this,
+// so unmatched categories fall back to CategoryOther rather than guessing.
+var foursquareCategoryMap = map[string]string{
+	"restaurant":    CategoryRestaurant,
+	"coffee shop":   CategoryCafe,
+	"café":          CategoryCafe,
+	"bar":           CategoryBar,
+	"nightlife spot": CategoryBar,
+	"shop":          CategoryShop,
+	"retail":        CategoryShop,
+	"grocery store": CategoryGrocery,
+	"gym":           CategoryFitness,
+	"fitness center": CategoryFitness,
+	"park":          CategoryPark,
+	"hotel":         CategoryHotel,
+	"movie theater": CategoryEntertainment,
+}
+
+// googleCategoryMap maps Google Places "types" values to the shared taxonomy.
+var googleCategoryMap = map[string]string{
+	"restaurant":    CategoryRestaurant,
+	"cafe":          CategoryCafe,
+	"bar":           CategoryBar,
+	"store":         CategoryShop,
+	"clothing_store": CategoryShop,
+	"grocery_or_supermarket": CategoryGrocery,
+	"gym":           CategoryFitness,
+	"park":          CategoryPark,
+	"lodging":       CategoryHotel,
+	"movie_theater": CategoryEntertainment,
+}
+
+// osmCategoryMap maps OpenStreetMap amenity/shop tag values to the shared taxonomy.
+var osmCategoryMap = map[string]string{
+	"restaurant": CategoryRestaurant,
+	"cafe":       CategoryCafe,
+	"bar":        CategoryBar,
+	"pub":        CategoryBar,
+	"shop":       CategoryShop,
+	"supermarket": CategoryGrocery,
+	"gym":        CategoryFitness,
+	"fitness_centre": CategoryFitness,
+	"park":       CategoryPark,
+	"hotel":      CategoryHotel,
+	"cinema":     CategoryEntertainment,
+}
+
+// normalizeCategory translates a provider-native category label into the
+// shared taxonomy, falling back to CategoryOther for anything unmapped.
+func normalizeCategory(provider, raw string) string {
+	key := strings.ToLower(strings.TrimSpace(raw))
+
+	var table map[string]string
+	switch provider {
+	case "google":
+		table = googleCategoryMap
+	case "osm":
+		table = osmCategoryMap
+	default:
+		table = foursquareCategoryMap
+	}
+
+	if normalized, ok := table[key]; ok {
+		return normalized
+	}
+	return CategoryOther
+}
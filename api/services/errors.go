@@ -0,0 +1,77 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Sentinel errors classifying upstream Foursquare (and Foursquare-compatible
+// provider) failures, modeled on the error taxonomy Google's Places client
+// exposes (INVALID_REQUEST, NOT_FOUND, OVER_QUERY_LIMIT, REQUEST_DENIED,
+// UNKNOWN_ERROR). statusError wraps whichever of these its HTTP status code
+// maps to, so callers can use the Is* helpers below instead of matching on
+// error text. Google's ZERO_RESULTS has no counterpart here: neither a
+// multi-result search finding nothing nor a single-place lookup by ID has a
+// legitimate "zero results" failure mode in this provider set - a search
+// just returns an empty slice, and an unresolvable ID is a 404 (ErrNotFound).
+var (
+	ErrInvalidRequest = errors.New("places provider: invalid request")
+	ErrNotFound       = errors.New("places provider: not found")
+	ErrOverQueryLimit = errors.New("places provider: over query limit")
+	ErrRequestDenied  = errors.New("places provider: request denied")
+	ErrUnknown        = errors.New("places provider: unknown upstream error")
+)
+
+// IsInvalidRequest reports whether err (or an error it wraps) is ErrInvalidRequest.
+func IsInvalidRequest(err error) bool { return errors.Is(err, ErrInvalidRequest) }
+
+// IsNotFound reports whether err (or an error it wraps) is ErrNotFound.
+func IsNotFound(err error) bool { return errors.Is(err, ErrNotFound) }
+
+// IsOverQueryLimit reports whether err (or an error it wraps) is ErrOverQueryLimit.
+func IsOverQueryLimit(err error) bool { return errors.Is(err, ErrOverQueryLimit) }
+
+// IsRequestDenied reports whether err (or an error it wraps) is ErrRequestDenied.
+func IsRequestDenied(err error) bool { return errors.Is(err, ErrRequestDenied) }
+
+// IsUnknown reports whether err (or an error it wraps) is ErrUnknown.
+func IsUnknown(err error) bool { return errors.Is(err, ErrUnknown) }
+
+// classifyStatusCode maps an upstream HTTP status code to the sentinel error
+// it corresponds to. Used by statusError.Unwrap so any error built from a
+// non-200 response is automatically classifiable via the Is* helpers.
+func classifyStatusCode(code int) error {
+	switch code {
+	case http.StatusBadRequest:
+		return ErrInvalidRequest
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrOverQueryLimit
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrRequestDenied
+	default:
+		return ErrUnknown
+	}
+}
+
+// ErrorResponseCode maps one of the sentinel errors above (however deeply
+// wrapped) to the stable HTTP status HTTP handlers should report, so every
+// endpoint surfaces the same code for the same upstream failure class
+// instead of each handler picking its own. Falls back to 502 Bad Gateway,
+// since an unclassified upstream failure is this service's fault for
+// depending on a provider that broke, not the caller's fault.
+func ErrorResponseCode(err error) int {
+	switch {
+	case IsInvalidRequest(err):
+		return http.StatusBadRequest
+	case IsNotFound(err):
+		return http.StatusNotFound
+	case IsOverQueryLimit(err):
+		return http.StatusTooManyRequests
+	case IsRequestDenied(err):
+		return http.StatusForbidden
+	default:
+		return http.StatusBadGateway
+	}
+}
@@ -1,40 +1,161 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"placeintel-pro/api/middleware"
 	"placeintel-pro/api/models"
+	"placeintel-pro/api/services/resilience"
 )
 
+var foursquareTracer = otel.Tracer(middleware.TracerName)
+
 const (
 	FoursquareAPIBase = "https://api.foursquare.com/v3"
 	DefaultRadius     = 1000
 	DefaultLimit      = 20
 )
 
+// Resilient upstream configuration, modeled on photoprism's places client:
+// operators can tune the retry backoff independently of the breaker above,
+// and declare mirrors/proxies to fail over to once a given endpoint
+// exhausts its retries.
+var (
+	// Retries is the number of retry attempts per endpoint after the
+	// initial try, before failing over to the next entry in ServiceUrls.
+	Retries = 2
+	// RetryDelay is the base backoff between attempts on a given endpoint;
+	// actual sleep is RetryDelay*2^attempt plus jitter, capped at 2s.
+	RetryDelay = 100 * time.Millisecond
+	// ServiceUrls lists the Foursquare API base URLs to try, in order.
+	// Defaults to the public API; set FOURSQUARE_SERVICE_URLS (comma
+	// separated) to add mirrors/proxies.
+	ServiceUrls = foursquareServiceURLsFromEnv()
+)
+
+func foursquareServiceURLsFromEnv() []string {
+	raw := os.Getenv("FOURSQUARE_SERVICE_URLS")
+	if raw == "" {
+		return []string{FoursquareAPIBase}
+	}
+
+	var urls []string
+	for _, u := range strings.Split(raw, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	if len(urls) == 0 {
+		return []string{FoursquareAPIBase}
+	}
+	return urls
+}
+
+// AllFields lists every Foursquare v3 "fields" name this client knows how to
+// unmarshal into models.FoursquarePlace / FoursquarePlaceDetails. Callers
+// pass a comma-separated subset (e.g. via PlaceSearchRequest.Fields) through
+// fieldsParam to avoid paying for payload they don't use.
+var AllFields = []string{
+	"fsq_id", "name", "location", "categories", "distance",
+	"tel", "website", "email", "description",
+	"hours", "hours_popular", "rating", "stats", "price", "popularity",
+	"photos", "tips", "social_media", "chains",
+	"date_closed", "closed_bucket", "features",
+}
+
+// DefaultSearchFields is the field set SearchPlaces requests when the caller
+// didn't ask for a specific subset.
+var DefaultSearchFields = []string{"fsq_id", "name", "location", "categories", "distance", "tel", "website", "rating", "price", "hours"}
+
+// DefaultDetailsFields is the field set GetPlaceDetails requests - richer
+// than search, since a details lookup is for a single place the caller
+// already cares about.
+var DefaultDetailsFields = []string{
+	"fsq_id", "name", "location", "categories",
+	"tel", "website", "email", "description",
+	"hours", "hours_popular", "rating", "stats", "price", "popularity",
+	"photos", "tips", "social_media", "chains",
+	"date_closed", "closed_bucket", "features",
+}
+
+// fieldsParam builds the "fields" query value for a Foursquare request.
+// requested is a caller-supplied comma-separated subset of AllFields (e.g.
+// from PlaceSearchRequest.Fields); unknown names are dropped, and an empty
+// or fully-invalid value falls back to fallback.
+func fieldsParam(requested string, fallback []string) string {
+	if requested == "" {
+		return strings.Join(fallback, ",")
+	}
+
+	known := make(map[string]bool, len(AllFields))
+	for _, f := range AllFields {
+		known[f] = true
+	}
+
+	var kept []string
+	for _, f := range strings.Split(requested, ",") {
+		if f = strings.TrimSpace(f); known[f] {
+			kept = append(kept, f)
+		}
+	}
+	if len(kept) == 0 {
+		return strings.Join(fallback, ",")
+	}
+	return strings.Join(kept, ",")
+}
+
 // FoursquareService handles interactions with Foursquare Places API
 type FoursquareService struct {
 	apiKey     string
 	httpClient *http.Client
+	breaker    *resilience.Breaker
+	retryCfg   resilience.RetryConfig
 }
 
-// NewFoursquareService creates a new Foursquare service instance
+// NewFoursquareService creates a new Foursquare service instance.
 func NewFoursquareService(apiKey string) *FoursquareService {
+	retryCfg := resilience.RetryConfig{
+		MaxAttempts: Retries + 1,
+		BaseDelay:   RetryDelay,
+		MaxDelay:    2 * time.Second,
+	}
+	if envCfg := resilience.RetryConfigFromEnv("FOURSQUARE"); envCfg != resilience.DefaultRetryConfig() {
+		retryCfg = envCfg
+	}
+
 	return &FoursquareService{
 		apiKey: apiKey,
 		httpClient: &http.Client{
-			Timeout: 10 * time.Second,
+			Timeout:   10 * time.Second,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
 		},
+		breaker:  resilience.NewBreaker("foursquare", resilience.BreakerConfigFromEnv("FOURSQUARE")),
+		retryCfg: retryCfg,
 	}
 }
 
+// Close releases idle connections held by the Foursquare HTTP client.
+func (fs *FoursquareService) Close() error {
+	fs.httpClient.CloseIdleConnections()
+	return nil
+}
+
 // FoursquareSearchResponse represents the response from Foursquare search API
 type FoursquareSearchResponse struct {
 	Results []models.FoursquarePlace `json:"results"`
@@ -73,31 +194,34 @@ type FoursquarePlaceDetails struct {
 			Suffix string `json:"suffix"`
 		} `json:"icon"`
 	} `json:"categories"`
-	Tel         string `json:"tel"`
-	Website     string `json:"website"`
-	Email       string `json:"email"`
-	Description string `json:"description"`
-	Hours       struct {
-		Display   string `json:"display"`
-		IsLocalHoliday bool `json:"is_local_holiday"`
-		OpenNow   bool   `json:"open_now"`
-		Regular   []struct {
-			Close string `json:"close"`
-			Day   int    `json:"day"`
-			Open  string `json:"open"`
-		} `json:"regular"`
-	} `json:"hours"`
-	Rating  float64 `json:"rating"`
-	Stats   struct {
-		TotalPhotos   int `json:"total_photos"`
-		TotalRatings  int `json:"total_ratings"`
-		TotalTips     int `json:"total_tips"`
-	} `json:"stats"`
-	Price int `json:"price"`
+	Tel          string                     `json:"tel"`
+	Website      string                     `json:"website"`
+	Email        string                     `json:"email"`
+	Description  string                     `json:"description"`
+	Hours        models.PlaceHours          `json:"hours"`
+	HoursPopular []models.PopularHoursBlock `json:"hours_popular,omitempty"`
+	Rating       float64                    `json:"rating"`
+	Stats        models.PlaceStats          `json:"stats"`
+	Price        int                        `json:"price"`
+	Popularity   float64                    `json:"popularity,omitempty"`
+	Photos       []models.PlacePhoto        `json:"photos,omitempty"`
+	Tips         []models.PlaceTip          `json:"tips,omitempty"`
+	SocialMedia  *models.PlaceSocialMedia   `json:"social_media,omitempty"`
+	Chains       []models.PlaceChain        `json:"chains,omitempty"`
+	DateClosed   *models.CustomTime         `json:"date_closed,omitempty"`
+	ClosedBucket string                     `json:"closed_bucket,omitempty"`
+	Features     *models.PlaceFeatures      `json:"features,omitempty"`
 }
 
 // SearchPlaces searches for places using Foursquare Places API
-func (fs *FoursquareService) SearchPlaces(req models.PlaceSearchRequest) ([]models.FoursquarePlace, error) {
+func (fs *FoursquareService) SearchPlaces(ctx context.Context, req models.PlaceSearchRequest) ([]models.FoursquarePlace, error) {
+	ctx, span := foursquareTracer.Start(ctx, "foursquare.SearchPlaces")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("query", req.Query),
+		attribute.Int("radius", req.Radius),
+	)
+
 	// Build query parameters
 	params := url.Values{}
 	params.Add("ll", fmt.Sprintf("%.6f,%.6f", req.Latitude, req.Longitude))
@@ -123,21 +247,22 @@ func (fs *FoursquareService) SearchPlaces(req models.PlaceSearchRequest) ([]mode
 	params.Add("limit", strconv.Itoa(limit))
 	
 	// Add additional useful fields
-	params.Add("fields", "fsq_id,name,location,categories,distance,tel,website,rating,price,hours")
+	params.Add("fields", fieldsParam(req.Fields, DefaultSearchFields))
 
 	// Make API request
-	apiURL := fmt.Sprintf("%s/places/search?%s", FoursquareAPIBase, params.Encode())
-	
+	path := fmt.Sprintf("/places/search?%s", params.Encode())
+
 	logrus.WithFields(logrus.Fields{
-		"url":    apiURL,
+		"path":   path,
 		"query":  req.Query,
 		"lat":    req.Latitude,
 		"lng":    req.Longitude,
 		"radius": radius,
 	}).Info("Searching places via Foursquare API")
 
-	resp, err := fs.makeRequest("GET", apiURL)
+	resp, err := fs.makeRequest(ctx, "GET", path, "search")
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		return nil, fmt.Errorf("failed to search places: %w", err)
 	}
 
@@ -147,22 +272,38 @@ func (fs *FoursquareService) SearchPlaces(req models.PlaceSearchRequest) ([]mode
 	}
 
 	logrus.WithField("results_count", len(searchResp.Results)).Info("Places search completed")
+	// A search legitimately finding nothing (sparse area, tight radius) is
+	// not a failure - return it the same way a non-empty search does, with
+	// a nil error and an empty slice, rather than a sentinel error.
+	for i := range searchResp.Results {
+		searchResp.Results[i].Sources = []string{fs.Name()}
+	}
 	return searchResp.Results, nil
 }
 
+// Name identifies this provider in MultiProvider's fusion output and
+// per-provider health reporting. Matches the breaker name above.
+func (fs *FoursquareService) Name() string {
+	return "foursquare"
+}
+
 // GetPlaceDetails retrieves detailed information for a specific place
-func (fs *FoursquareService) GetPlaceDetails(placeID string) (*FoursquarePlaceDetails, error) {
-	// Build API URL with comprehensive fields
-	fields := "fsq_id,name,location,categories,tel,website,email,description,hours,rating,stats,price,photos"
-	apiURL := fmt.Sprintf("%s/places/%s?fields=%s", FoursquareAPIBase, placeID, fields)
+func (fs *FoursquareService) GetPlaceDetails(ctx context.Context, placeID string) (*FoursquarePlaceDetails, error) {
+	ctx, span := foursquareTracer.Start(ctx, "foursquare.GetPlaceDetails")
+	defer span.End()
+	span.SetAttributes(attribute.String("place_id", placeID))
+
+	// Build request path with comprehensive fields
+	path := fmt.Sprintf("/places/%s?fields=%s", placeID, fieldsParam("", DefaultDetailsFields))
 
 	logrus.WithFields(logrus.Fields{
 		"place_id": placeID,
-		"url":      apiURL,
+		"path":     path,
 	}).Info("Fetching place details via Foursquare API")
 
-	resp, err := fs.makeRequest("GET", apiURL)
+	resp, err := fs.makeRequest(ctx, "GET", path, "details")
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		return nil, fmt.Errorf("failed to get place details: %w", err)
 	}
 
@@ -175,9 +316,94 @@ func (fs *FoursquareService) GetPlaceDetails(placeID string) (*FoursquarePlaceDe
 	return &placeDetails, nil
 }
 
-// makeRequest makes an HTTP request to Foursquare API with proper authentication
-func (fs *FoursquareService) makeRequest(method, url string) ([]byte, error) {
-	req, err := http.NewRequest(method, url, nil)
+// statusError carries the HTTP status and any Retry-After hint from a
+// non-200 Foursquare response, so the retry classifier can decide whether
+// it's worth trying again without re-parsing the response.
+type statusError struct {
+	statusCode int
+	retryAfter time.Duration
+	body       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("API request failed with status %d: %s", e.statusCode, e.body)
+}
+
+// Unwrap exposes the sentinel error matching e.statusCode, so callers can
+// use errors.Is/IsNotFound/IsOverQueryLimit/etc. against whatever error
+// makeRequest returned without knowing it was a *statusError underneath.
+func (e *statusError) Unwrap() error {
+	return classifyStatusCode(e.statusCode)
+}
+
+func isRetriableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code == http.StatusBadGateway ||
+		code == http.StatusServiceUnavailable || code == http.StatusGatewayTimeout
+}
+
+// classifyFoursquareError decides whether an error from an attempt is worth
+// retrying: network errors and 429/502/503/504 are retriable (honoring
+// Retry-After when present), everything else short-circuits.
+func classifyFoursquareError(err error) (bool, time.Duration) {
+	var se *statusError
+	if errors.As(err, &se) {
+		return isRetriableStatus(se.statusCode), se.retryAfter
+	}
+	return true, 0
+}
+
+// makeRequest resolves path against every entry in ServiceUrls in order,
+// propagating the caller's trace context, retrying retriable failures
+// against the current URL with backoff, and failing over to the next URL
+// once its retries are exhausted. The whole attempt is shed via the
+// Foursquare circuit breaker when the dependency is unhealthy. It records
+// RED metrics per endpoint and logs a failover/retry summary so operators
+// can alert on upstream degradation.
+func (fs *FoursquareService) makeRequest(ctx context.Context, method, path, endpoint string) ([]byte, error) {
+	var body []byte
+
+	err := fs.breaker.Execute(func() error {
+		var lastErr error
+
+		for i, baseURL := range ServiceUrls {
+			fullURL := baseURL + path
+
+			attemptErr := resilience.Do(ctx, "foursquare", fs.retryCfg, classifyFoursquareError, func() error {
+				b, err := fs.doRequest(ctx, method, fullURL, endpoint)
+				if err != nil {
+					return err
+				}
+				body = b
+				return nil
+			})
+			if attemptErr == nil {
+				return nil
+			}
+
+			lastErr = attemptErr
+			logrus.WithFields(logrus.Fields{
+				"endpoint":      endpoint,
+				"base_url":      baseURL,
+				"url_index":     i,
+				"urls_remaining": len(ServiceUrls) - i - 1,
+				"error":         attemptErr,
+			}).Warn("Foursquare endpoint exhausted retries, failing over to next mirror")
+		}
+
+		return lastErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// doRequest performs a single HTTP attempt and records per-attempt metrics.
+func (fs *FoursquareService) doRequest(ctx context.Context, method, url, endpoint string) ([]byte, error) {
+	start := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -187,7 +413,9 @@ func (fs *FoursquareService) makeRequest(method, url string) ([]byte, error) {
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := fs.httpClient.Do(req)
+	middleware.FoursquareRequestDuration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
 	if err != nil {
+		middleware.FoursquareRequestsTotal.WithLabelValues(endpoint, "error").Inc()
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
@@ -197,26 +425,47 @@ func (fs *FoursquareService) makeRequest(method, url string) ([]byte, error) {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	middleware.FoursquareRequestsTotal.WithLabelValues(endpoint, strconv.Itoa(resp.StatusCode)).Inc()
+
 	if resp.StatusCode != http.StatusOK {
 		logrus.WithFields(logrus.Fields{
 			"status_code": resp.StatusCode,
 			"response":    string(body),
 		}).Error("Foursquare API request failed")
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, &statusError{
+			statusCode: resp.StatusCode,
+			retryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			body:       string(body),
+		}
 	}
 
 	return body, nil
 }
 
+// parseRetryAfter supports both the delay-seconds and HTTP-date forms of
+// the Retry-After header; it returns 0 if the header is absent or invalid.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		return time.Until(when)
+	}
+	return 0
+}
+
 // HealthCheck verifies connectivity to Foursquare API
-func (fs *FoursquareService) HealthCheck() error {
+func (fs *FoursquareService) HealthCheck(ctx context.Context) error {
 	// Make a simple search request to verify API connectivity
-	testURL := fmt.Sprintf("%s/places/search?ll=40.7128,-74.0060&limit=1", FoursquareAPIBase)
-	
-	_, err := fs.makeRequest("GET", testURL)
+	testPath := "/places/search?ll=40.7128,-74.0060&limit=1"
+
+	_, err := fs.makeRequest(ctx, "GET", testPath, "health")
 	if err != nil {
 		return fmt.Errorf("foursquare API health check failed: %w", err)
 	}
-	
+
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,22 @@
+package services
+
+import "math"
+
+const earthRadiusMeters = 6371000.0
+
+// haversineDistanceMeters returns the great-circle distance between two
+// lat/lng points in meters. Shared by every provider that needs to dedup or
+// filter results by real-world distance rather than a provider's own
+// (sometimes approximate) distance field.
+func haversineDistanceMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	return earthRadiusMeters * c
+}
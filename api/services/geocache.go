@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/geo/s2"
+
+	"placeintel-pro/api/models"
+)
+
+// PopularPlacesRadius is the fixed search radius used for popular-places
+// queries; it also determines the S2 cell level those results are cached at.
+const PopularPlacesRadius = 2000
+
+// maxParentCellLookups bounds how many coarser S2 cells
+// GetCachedSearchResultsNear walks up to before treating a lookup as a miss.
+const maxParentCellLookups = 3
+
+// s2LevelForRadius returns the S2 cell level whose typical cell size covers
+// the requested search radius, so two searches at similar radii land on the
+// same cell and can share a cache entry without needing identical
+// coordinates. Mirrors the level/radius bands PhotoPrism uses for its places
+// cache (roughly level 10 ~ 5km down to level 16 ~ 120m).
+func s2LevelForRadius(radiusMeters int) int {
+	switch {
+	case radiusMeters <= 150:
+		return 16
+	case radiusMeters <= 300:
+		return 15
+	case radiusMeters <= 600:
+		return 14
+	case radiusMeters <= 1200:
+		return 13
+	case radiusMeters <= 2500:
+		return 12
+	case radiusMeters <= 5000:
+		return 11
+	default:
+		return 10
+	}
+}
+
+// s2TokenAt returns the token of the S2 cell containing (lat, lng) at level.
+func s2TokenAt(lat, lng float64, level int) string {
+	return s2.CellIDFromLatLng(s2.LatLngFromDegrees(lat, lng)).Parent(level).ToToken()
+}
+
+// s2ParentTokens returns the tokens of up to maxLevels cells above the cell
+// identified by token, nearest ancestor first. Used to check progressively
+// coarser cache entries when the exact cell misses.
+func s2ParentTokens(token string, maxLevels int) []string {
+	cellID := s2.CellIDFromToken(token)
+	level := cellID.Level()
+
+	tokens := make([]string, 0, maxLevels)
+	for i := 1; i <= maxLevels && level-i >= 0; i++ {
+		tokens = append(tokens, cellID.Parent(level-i).ToToken())
+	}
+	return tokens
+}
+
+// searchCacheKey builds the S2-cell-based key body for a search cache entry.
+// CacheSearchResults/GetCachedSearchResults add the PlaceSearchPrefix.
+func searchCacheKey(cellToken, query, categories string, limit int) string {
+	return fmt.Sprintf("%s:%s:%s:%d", cellToken, query, categories, limit)
+}
+
+// GetCachedSearchResultsNear looks up cached search results for a point by
+// S2 cell: it first checks the exact cell covering (lat, lng, radius), then
+// walks up to maxParentCellLookups parent cells. A parent-cell hit is
+// filtered by real haversine distance to (lat, lng) before being returned,
+// so a coarser cell's cached results never leak places outside the caller's
+// requested radius. It always returns the exact-cell cache key so the
+// caller can write back to it on a miss.
+func (cs *CacheService) GetCachedSearchResultsNear(ctx context.Context, lat, lng float64, radius int, query, categories string, limit int) ([]models.PlaceIntelligence, string, error) {
+	if radius == 0 {
+		radius = DefaultRadius
+	}
+
+	token := s2TokenAt(lat, lng, s2LevelForRadius(radius))
+	cacheKey := searchCacheKey(token, query, categories, limit)
+
+	if results, err := cs.GetCachedSearchResults(ctx, cacheKey); err == nil && results != nil {
+		return results, cacheKey, nil
+	}
+
+	for _, parentToken := range s2ParentTokens(token, maxParentCellLookups) {
+		parentKey := searchCacheKey(parentToken, query, categories, limit)
+		results, err := cs.GetCachedSearchResults(ctx, parentKey)
+		if err != nil || results == nil {
+			continue
+		}
+
+		filtered := make([]models.PlaceIntelligence, 0, len(results))
+		for _, place := range results {
+			placeLat, placeLng, ok := placeLatLng(place)
+			if !ok {
+				continue
+			}
+			if haversineDistanceMeters(lat, lng, placeLat, placeLng) <= float64(radius) {
+				filtered = append(filtered, place)
+			}
+		}
+		if len(filtered) > 0 {
+			return filtered, cacheKey, nil
+		}
+	}
+
+	return nil, cacheKey, nil
+}
+
+// placeLatLng recovers a cached PlaceIntelligence's coordinates for the
+// haversine filter above. PlaceIntelligence.Location is interface{} - it
+// holds whatever shape was decoded off the cache (typically a
+// FoursquarePlace.Location-shaped map), so it's re-encoded through JSON into
+// a typed struct rather than type-asserted directly, mirroring
+// PlaceIntelligence.ToV2's reencodeJSON approach. Returns ok=false if
+// Location is nil or doesn't carry lat/lng, so the caller can skip it
+// instead of filtering on (0, 0).
+func placeLatLng(place models.PlaceIntelligence) (lat, lng float64, ok bool) {
+	if place.Location == nil {
+		return 0, 0, false
+	}
+
+	data, err := json.Marshal(place.Location)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	var loc struct {
+		Latitude  float64 `json:"lat"`
+		Longitude float64 `json:"lng"`
+	}
+	if err := json.Unmarshal(data, &loc); err != nil {
+		return 0, 0, false
+	}
+
+	return loc.Latitude, loc.Longitude, true
+}
+
+// PopularPlacesCacheKey derives the S2-cell cache key for popular-places
+// caching. Unlike search caching it keys on the cell alone - popular places
+// always searches the same fixed PopularPlacesRadius, so there's no
+// query/categories/limit variation to fold into the key.
+func (cs *CacheService) PopularPlacesCacheKey(lat, lng float64) string {
+	return s2TokenAt(lat, lng, s2LevelForRadius(PopularPlacesRadius))
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"placeintel-pro/api/models"
+	"placeintel-pro/api/services/resilience"
+)
+
+const googlePlacesAPIBase = "https://maps.googleapis.com/maps/api/place"
+
+// GooglePlacesService implements PlacesProvider against the Google Places
+// API, for operators who aren't on Foursquare.
+type GooglePlacesService struct {
+	apiKey     string
+	httpClient *http.Client
+	breaker    *resilience.Breaker
+	retryCfg   resilience.RetryConfig
+}
+
+// NewGooglePlacesService creates a new Google Places provider instance.
+func NewGooglePlacesService(apiKey string) *GooglePlacesService {
+	return &GooglePlacesService{
+		apiKey: apiKey,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		breaker:  resilience.NewBreaker("google_places", resilience.BreakerConfigFromEnv("GOOGLE_PLACES")),
+		retryCfg: resilience.RetryConfigFromEnv("GOOGLE_PLACES"),
+	}
+}
+
+type googleNearbySearchResponse struct {
+	Results []googlePlaceResult `json:"results"`
+	Status  string              `json:"status"`
+}
+
+type googlePlaceResult struct {
+	PlaceID  string   `json:"place_id"`
+	Name     string   `json:"name"`
+	Types    []string `json:"types"`
+	Geometry struct {
+		Location struct {
+			Lat float64 `json:"lat"`
+			Lng float64 `json:"lng"`
+		} `json:"location"`
+	} `json:"geometry"`
+	Vicinity string  `json:"vicinity"`
+	Rating   float64 `json:"rating"`
+	PriceLvl int     `json:"price_level"`
+}
+
+// SearchPlaces queries the Google Places Nearby Search endpoint and
+// normalizes the response into the shared FoursquarePlace shape so callers
+// remain provider-agnostic.
+func (gs *GooglePlacesService) SearchPlaces(ctx context.Context, req models.PlaceSearchRequest) ([]models.FoursquarePlace, error) {
+	params := url.Values{}
+	params.Set("location", fmt.Sprintf("%.6f,%.6f", req.Latitude, req.Longitude))
+
+	radius := req.Radius
+	if radius == 0 {
+		radius = DefaultRadius
+	}
+	params.Set("radius", strconv.Itoa(radius))
+
+	if req.Query != "" {
+		params.Set("keyword", req.Query)
+	}
+	if req.Categories != "" {
+		params.Set("type", req.Categories)
+	}
+	params.Set("key", gs.apiKey)
+
+	apiURL := fmt.Sprintf("%s/nearbysearch/json?%s", googlePlacesAPIBase, params.Encode())
+
+	body, err := gs.get(ctx, apiURL, "search")
+	if err != nil {
+		return nil, fmt.Errorf("failed to search places via Google Places: %w", err)
+	}
+
+	var searchResp googleNearbySearchResponse
+	if err := json.Unmarshal(body, &searchResp); err != nil {
+		return nil, fmt.Errorf("failed to parse Google Places search response: %w", err)
+	}
+	if searchResp.Status != "OK" && searchResp.Status != "ZERO_RESULTS" {
+		return nil, fmt.Errorf("google places search returned status %s", searchResp.Status)
+	}
+
+	places := make([]models.FoursquarePlace, 0, len(searchResp.Results))
+	for _, r := range searchResp.Results {
+		place := toFoursquarePlace(r, req.Latitude, req.Longitude)
+		place.Sources = []string{gs.Name()}
+		places = append(places, place)
+	}
+
+	limit := req.Limit
+	if limit > 0 && limit < len(places) {
+		places = places[:limit]
+	}
+
+	return places, nil
+}
+
+// GetPlaceDetails fetches Google's Place Details endpoint and normalizes it
+// into the shared FoursquarePlaceDetails shape.
+func (gs *GooglePlacesService) GetPlaceDetails(ctx context.Context, placeID string) (*FoursquarePlaceDetails, error) {
+	params := url.Values{}
+	params.Set("place_id", placeID)
+	params.Set("fields", "place_id,name,geometry,formatted_address,formatted_phone_number,website,rating,price_level,opening_hours")
+	params.Set("key", gs.apiKey)
+
+	apiURL := fmt.Sprintf("%s/details/json?%s", googlePlacesAPIBase, params.Encode())
+
+	body, err := gs.get(ctx, apiURL, "details")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get place details via Google Places: %w", err)
+	}
+
+	var detailsResp struct {
+		Result googlePlaceDetailsResult `json:"result"`
+		Status string                   `json:"status"`
+	}
+	if err := json.Unmarshal(body, &detailsResp); err != nil {
+		return nil, fmt.Errorf("failed to parse Google Places details response: %w", err)
+	}
+	if detailsResp.Status != "OK" {
+		return nil, fmt.Errorf("google places details returned status %s", detailsResp.Status)
+	}
+
+	return toFoursquarePlaceDetails(detailsResp.Result), nil
+}
+
+type googlePlaceDetailsResult struct {
+	PlaceID  string `json:"place_id"`
+	Name     string `json:"name"`
+	Geometry struct {
+		Location struct {
+			Lat float64 `json:"lat"`
+			Lng float64 `json:"lng"`
+		} `json:"location"`
+	} `json:"geometry"`
+	FormattedAddress string  `json:"formatted_address"`
+	Phone            string  `json:"formatted_phone_number"`
+	Website          string  `json:"website"`
+	Rating           float64 `json:"rating"`
+	PriceLevel       int     `json:"price_level"`
+}
+
+func toFoursquarePlace(r googlePlaceResult, originLat, originLng float64) models.FoursquarePlace {
+	place := models.FoursquarePlace{
+		FSQId: r.PlaceID,
+		Name:  r.Name,
+	}
+	place.Location.Address = r.Vicinity
+	place.Location.Latitude = r.Geometry.Location.Lat
+	place.Location.Longitude = r.Geometry.Location.Lng
+	place.Distance = int(haversineDistanceMeters(originLat, originLng, r.Geometry.Location.Lat, r.Geometry.Location.Lng))
+
+	for i, t := range r.Types {
+		place.Categories = append(place.Categories, struct {
+			Id   int    `json:"id"`
+			Name string `json:"name"`
+			Icon struct {
+				Prefix string `json:"prefix"`
+				Suffix string `json:"suffix"`
+			} `json:"icon"`
+		}{
+			Id:   i,
+			Name: normalizeCategory("google", t),
+		})
+	}
+
+	return place
+}
+
+func toFoursquarePlaceDetails(r googlePlaceDetailsResult) *FoursquarePlaceDetails {
+	details := &FoursquarePlaceDetails{
+		FSQId:   r.PlaceID,
+		Name:    r.Name,
+		Tel:     r.Phone,
+		Website: r.Website,
+		Rating:  r.Rating,
+		Price:   r.PriceLevel,
+	}
+	details.Location.Address = r.FormattedAddress
+	details.Location.Latitude = r.Geometry.Location.Lat
+	details.Location.Longitude = r.Geometry.Location.Lng
+	return details
+}
+
+// get performs a retried, circuit-broken GET request against the Google
+// Places API, mirroring the resilience pattern used for Foursquare.
+func (gs *GooglePlacesService) get(ctx context.Context, apiURL, endpoint string) ([]byte, error) {
+	var body []byte
+
+	err := gs.breaker.Execute(func() error {
+		return resilience.Do(ctx, "google_places", gs.retryCfg, classifyFoursquareError, func() error {
+			req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
+			if err != nil {
+				return fmt.Errorf("failed to create request: %w", err)
+			}
+
+			resp, err := gs.httpClient.Do(req)
+			if err != nil {
+				return fmt.Errorf("failed to make request: %w", err)
+			}
+			defer resp.Body.Close()
+
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return fmt.Errorf("failed to read response body: %w", err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				return &statusError{statusCode: resp.StatusCode, retryAfter: parseRetryAfter(resp.Header.Get("Retry-After")), body: string(b)}
+			}
+
+			body = b
+			return nil
+		})
+	})
+
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"endpoint": endpoint, "error": err}).Error("Google Places API request failed")
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// HealthCheck verifies connectivity to the Google Places API.
+func (gs *GooglePlacesService) HealthCheck(ctx context.Context) error {
+	testURL := fmt.Sprintf("%s/nearbysearch/json?location=40.7128,-74.0060&radius=1000&key=%s", googlePlacesAPIBase, gs.apiKey)
+	_, err := gs.get(ctx, testURL, "health")
+	if err != nil {
+		return fmt.Errorf("google places health check failed: %w", err)
+	}
+	return nil
+}
+
+// Name identifies this provider in MultiProvider's fusion output and
+// per-provider health reporting. Matches the breaker name above.
+func (gs *GooglePlacesService) Name() string {
+	return "google_places"
+}
+
+var _ PlacesProvider = (*GooglePlacesService)(nil)
@@ -2,30 +2,87 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	stderrs "errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/sync/errgroup"
+
+	"placeintel-pro/api/middleware"
 	"placeintel-pro/api/models"
+	"placeintel-pro/api/services/resilience"
+	"placeintel-pro/api/services/weather"
 )
 
-// IntelligenceService handles communication with Python ML/AI engine
+// maxConcurrentEnhancements bounds how many HTTP enhancement requests the
+// fallback (non-gRPC) path runs at once.
+const maxConcurrentEnhancements = 5
+
+var intelligenceTracer = otel.Tracer(middleware.TracerName)
+
+// IntelligenceService handles communication with Python ML/AI engine. The
+// transport defaults to JSON-over-HTTP; setting INTELLIGENCE_TRANSPORT=grpc
+// switches to the gRPC client defined in api/proto/intelligence/v1, which
+// also unlocks streaming batch enhancement. HTTP remains available as a
+// fallback even when gRPC is selected, in case the gRPC dial fails.
 type IntelligenceService struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL         string
+	httpClient      *http.Client
+	grpc            *grpcIntelligenceClient
+	breaker         *resilience.Breaker
+	retryCfg        resilience.RetryConfig
+	weatherProvider weather.Provider
+	cacheService    *CacheService
 }
 
-// NewIntelligenceService creates a new intelligence service instance
-func NewIntelligenceService(baseURL string) *IntelligenceService {
-	return &IntelligenceService{
+// NewIntelligenceService creates a new intelligence service instance.
+// cacheService is the same CacheService the realtime Poller writes to, so a
+// place the poller already has fresh data for doesn't have to be recomputed
+// from scratch; it may be nil in tests that don't exercise that path.
+func NewIntelligenceService(baseURL string, cacheService *CacheService) *IntelligenceService {
+	is := &IntelligenceService{
 		baseURL: baseURL,
 		httpClient: &http.Client{
-			Timeout: 15 * time.Second,
+			Timeout:   15 * time.Second,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
 		},
+		breaker:         resilience.NewBreaker("intelligence", resilience.BreakerConfigFromEnv("INTELLIGENCE")),
+		retryCfg:        resilience.RetryConfigFromEnv("INTELLIGENCE"),
+		weatherProvider: newWeatherProviderFromEnv(),
+		cacheService:    cacheService,
+	}
+
+	if getEnv("INTELLIGENCE_TRANSPORT", "http") == "grpc" {
+		grpcAddr := getEnv("INTELLIGENCE_GRPC_ADDR", baseURL)
+		client, err := newGRPCIntelligenceClient(grpcAddr)
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to dial intelligence gRPC service, falling back to HTTP")
+		} else {
+			is.grpc = client
+		}
 	}
+
+	return is
+}
+
+// Close releases the gRPC connection, if one was established, and the HTTP
+// client's idle connections.
+func (is *IntelligenceService) Close() error {
+	is.httpClient.CloseIdleConnections()
+	if is.grpc != nil {
+		return is.grpc.Close()
+	}
+	return nil
 }
 
 // IntelligenceRequest represents the request to Python intelligence service
@@ -46,9 +103,13 @@ type IntelligenceResponse struct {
 }
 
 // EnhancePlaceWithIntelligence enriches place data with AI-generated intelligence
-func (is *IntelligenceService) EnhancePlaceWithIntelligence(place models.FoursquarePlace) (*models.PlaceIntelligence, error) {
+func (is *IntelligenceService) EnhancePlaceWithIntelligence(ctx context.Context, place models.FoursquarePlace) (*models.PlaceIntelligence, error) {
 	startTime := time.Now()
 
+	ctx, span := intelligenceTracer.Start(ctx, "intelligence.EnhancePlaceWithIntelligence")
+	defer span.End()
+	span.SetAttributes(attribute.String("place_id", place.FSQId))
+
 	// Prepare request payload
 	req := IntelligenceRequest{
 		Place: place,
@@ -67,17 +128,37 @@ func (is *IntelligenceService) EnhancePlaceWithIntelligence(place models.Foursqu
 		},
 	}
 
-	// Make request to Python intelligence service
-	intelligenceResp, err := is.processIntelligence(req)
+	// Make request to the intelligence service over whichever transport is configured
+	var intelligenceResp *IntelligenceResponse
+	var err error
+	if is.grpc != nil {
+		intelligenceResp, err = is.grpc.enhance(ctx, place, req.Features)
+		if err != nil {
+			logrus.WithError(err).Warn("gRPC enhance call failed, falling back to HTTP")
+			intelligenceResp, err = is.processIntelligence(ctx, req)
+		}
+	} else {
+		intelligenceResp, err = is.processIntelligence(ctx, req)
+	}
 	if err != nil {
 		// If intelligence service fails, return basic place data with empty intelligence
+		span.SetStatus(codes.Error, err.Error())
 		logrus.WithError(err).Warn("Intelligence service failed, returning basic place data")
-		return is.createBasicPlaceIntelligence(place, time.Since(startTime)), nil
+		return is.createBasicPlaceIntelligence(ctx, place, time.Since(startTime)), nil
+	}
+
+	// The realtime Poller may already be tracking this place and have a more
+	// current RealTimeContext than whatever the AI service just computed
+	// from a single snapshot - prefer it when available.
+	if cached := is.cachedRealTimeContext(ctx, place.FSQId); cached != nil {
+		intelligenceResp.RealTimeContext = *cached
 	}
 
+	is.applyWeather(ctx, place, &intelligenceResp.RealTimeContext, &intelligenceResp.BusinessIntelligence, &intelligenceResp.UnifiedRecommendations)
+
 	// Create enhanced place intelligence
 	placeIntel := &models.PlaceIntelligence{
-		FSQId:      place.FSQPlaceId,
+		FSQId:      place.FSQId,
 		Name:       place.Name,
 		Location:   place.Location,
 		Categories: place.Categories,
@@ -87,14 +168,15 @@ func (is *IntelligenceService) EnhancePlaceWithIntelligence(place models.Foursqu
 		RealTimeContext:           intelligenceResp.RealTimeContext,
 		AccessibilityIntelligence: intelligenceResp.AccessibilityIntelligence,
 		UnifiedRecommendations:    intelligenceResp.UnifiedRecommendations,
+		RawVenueDetails:           buildRawVenueDetails(place),
 
 		ProcessingTime: time.Since(startTime),
-		DataSources:    intelligenceResp.DataSources,
+		DataSources:    placeDataSources(place, intelligenceResp.DataSources),
 		LastUpdated:    time.Now().UTC(),
 	}
 
 	logrus.WithFields(logrus.Fields{
-		"place_id":        place.FSQPlaceId,
+		"place_id":        place.FSQId,
 		"place_name":      place.Name,
 		"processing_time": time.Since(startTime),
 	}).Info("Place intelligence enhancement completed")
@@ -102,66 +184,121 @@ func (is *IntelligenceService) EnhancePlaceWithIntelligence(place models.Foursqu
 	return placeIntel, nil
 }
 
-// EnhancePlacesWithIntelligence processes multiple places concurrently
-func (is *IntelligenceService) EnhancePlacesWithIntelligence(places []models.FoursquarePlace) ([]models.PlaceIntelligence, error) {
+// EnhancePlacesWithIntelligence processes multiple places, pipelining them
+// through the gRPC streaming RPC when it's available, or otherwise falling
+// back to N concurrent HTTP requests behind a semaphore.
+func (is *IntelligenceService) EnhancePlacesWithIntelligence(ctx context.Context, places []models.FoursquarePlace) ([]models.PlaceIntelligence, error) {
 	if len(places) == 0 {
 		return []models.PlaceIntelligence{}, nil
 	}
 
-	// Process places concurrently for better performance
+	if is.grpc != nil {
+		results, err := is.grpc.enhanceStream(ctx, places)
+		if err != nil {
+			logrus.WithError(err).Warn("gRPC streaming enhancement failed, falling back to HTTP")
+		} else {
+			return results, nil
+		}
+	}
+
+	// Process places concurrently, bounded by an errgroup so the pool
+	// respects cancellation of the caller's context instead of running
+	// fire-and-forget goroutines that outlive the request.
 	results := make([]models.PlaceIntelligence, len(places))
-	errors := make([]error, len(places))
-	
-	// Use a semaphore to limit concurrent requests
-	semaphore := make(chan struct{}, 5) // Max 5 concurrent requests
-	done := make(chan int, len(places))
+
+	g, ctx := errgroup.WithContext(ctx)
+	semaphore := make(chan struct{}, maxConcurrentEnhancements)
 
 	for i, place := range places {
-		go func(index int, p models.FoursquarePlace) {
-			semaphore <- struct{}{} // Acquire semaphore
-			defer func() { <-semaphore }() // Release semaphore
+		i, place := i, place
+		g.Go(func() error {
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				results[i] = *is.createBasicPlaceIntelligence(ctx, place, 0)
+				return nil
+			}
 
-			enhanced, err := is.EnhancePlaceWithIntelligence(p)
+			enhanced, err := is.EnhancePlaceWithIntelligence(ctx, place)
 			if err != nil {
-				errors[index] = err
-				// Create basic place intelligence on error
-				enhanced = is.createBasicPlaceIntelligence(p, 0)
+				logrus.WithFields(logrus.Fields{
+					"place_index": i,
+					"place_name":  place.Name,
+					"error":       err,
+				}).Warn("Failed to enhance place with intelligence")
+				enhanced = is.createBasicPlaceIntelligence(ctx, place, 0)
 			}
-			results[index] = *enhanced
-			done <- index
-		}(i, place)
+			results[i] = *enhanced
+			return nil
+		})
 	}
 
-	// Wait for all goroutines to complete
-	for i := 0; i < len(places); i++ {
-		<-done
-	}
-
-	// Log any errors but don't fail the entire request
-	for i, err := range errors {
-		if err != nil {
-			logrus.WithFields(logrus.Fields{
-				"place_index": i,
-				"place_name":  places[i].Name,
-				"error":       err,
-			}).Warn("Failed to enhance place with intelligence")
-		}
-	}
+	// Every goroutine above handles its own error, so this only ever
+	// surfaces a panic recovered by errgroup — never cancels sibling work.
+	_ = g.Wait()
 
 	return results, nil
 }
 
-// processIntelligence makes a request to the Python intelligence service
-func (is *IntelligenceService) processIntelligence(req IntelligenceRequest) (*IntelligenceResponse, error) {
-	// Serialize request
+// intelligenceStatusError carries the HTTP status and any Retry-After hint
+// from a non-200 intelligence service response.
+type intelligenceStatusError struct {
+	statusCode int
+	retryAfter time.Duration
+	body       string
+}
+
+func (e *intelligenceStatusError) Error() string {
+	return fmt.Sprintf("intelligence service returned status %d: %s", e.statusCode, e.body)
+}
+
+func classifyIntelligenceError(err error) (bool, time.Duration) {
+	var se *intelligenceStatusError
+	if stderrs.As(err, &se) {
+		return isRetriableStatus(se.statusCode), se.retryAfter
+	}
+	return true, 0
+}
+
+// processIntelligence makes a request to the Python intelligence service,
+// propagating the caller's trace context, retrying retriable failures with
+// backoff, and shedding load via the intelligence circuit breaker when the
+// dependency is unhealthy. Per-feature latency is recorded on every attempt.
+func (is *IntelligenceService) processIntelligence(ctx context.Context, req IntelligenceRequest) (*IntelligenceResponse, error) {
 	reqBody, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal intelligence request: %w", err)
 	}
 
-	// Make HTTP request
+	var body []byte
+	err = is.breaker.Execute(func() error {
+		return resilience.Do(ctx, "intelligence", is.retryCfg, classifyIntelligenceError, func() error {
+			b, err := is.doIntelligenceRequest(ctx, reqBody, req.Features)
+			if err != nil {
+				return err
+			}
+			body = b
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var intelligenceResp IntelligenceResponse
+	if err := json.Unmarshal(body, &intelligenceResp); err != nil {
+		return nil, fmt.Errorf("failed to parse intelligence response: %w", err)
+	}
+
+	return &intelligenceResp, nil
+}
+
+// doIntelligenceRequest performs a single HTTP attempt against the
+// intelligence service and records per-attempt, per-feature latency.
+func (is *IntelligenceService) doIntelligenceRequest(ctx context.Context, reqBody []byte, features []string) ([]byte, error) {
 	url := fmt.Sprintf("%s/api/v1/intelligence/enhance", is.baseURL)
-	httpReq, err := http.NewRequest("POST", url, bytes.NewBuffer(reqBody))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create intelligence request: %w", err)
 	}
@@ -169,7 +306,15 @@ func (is *IntelligenceService) processIntelligence(req IntelligenceRequest) (*In
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("Accept", "application/json")
 
+	start := time.Now()
 	resp, err := is.httpClient.Do(httpReq)
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	for _, feature := range features {
+		middleware.IntelligenceFeatureDuration.WithLabelValues(feature, status).Observe(time.Since(start).Seconds())
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to call intelligence service: %w", err)
 	}
@@ -181,29 +326,29 @@ func (is *IntelligenceService) processIntelligence(req IntelligenceRequest) (*In
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("intelligence service returned status %d: %s", resp.StatusCode, string(body))
-	}
-
-	// Parse response
-	var intelligenceResp IntelligenceResponse
-	if err := json.Unmarshal(body, &intelligenceResp); err != nil {
-		return nil, fmt.Errorf("failed to parse intelligence response: %w", err)
+		return nil, &intelligenceStatusError{
+			statusCode: resp.StatusCode,
+			retryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			body:       string(body),
+		}
 	}
 
-	return &intelligenceResp, nil
+	return body, nil
 }
 
 // createBasicPlaceIntelligence creates a basic place intelligence when AI service fails
-func (is *IntelligenceService) createBasicPlaceIntelligence(place models.FoursquarePlace, processingTime time.Duration) *models.PlaceIntelligence {
-	// Create basic intelligence with default values
+func (is *IntelligenceService) createBasicPlaceIntelligence(ctx context.Context, place models.FoursquarePlace, processingTime time.Duration) *models.PlaceIntelligence {
+	// With no AI service to call, fall back to what Foursquare itself
+	// reported (price, rating, feature flags) rather than inventing
+	// "unknown"/neutral placeholders across the board.
 	businessIntel := models.BusinessIntelligence{
-		PopularityScore: 5.0, // Default neutral score
-		SentimentScore:  3.5, // Default neutral sentiment
-		Specialties:     []string{},
+		PopularityScore: popularityScoreFromPlace(place),
+		SentimentScore:  sentimentScoreFromRating(place.Rating),
+		Specialties:     specialtiesFromFeatures(place.Features),
 		IdealFor:        []string{},
-		PriceRange:      "unknown",
-		Atmosphere:      "unknown",
-		TrendingScore:   0.0,
+		PriceRange:      priceRangeLabel(place.Price),
+		Atmosphere:      atmosphereLabel(place.Rating),
+		TrendingScore:   place.Popularity,
 	}
 
 	realTimeContext := models.RealTimeContext{
@@ -217,8 +362,18 @@ func (is *IntelligenceService) createBasicPlaceIntelligence(place models.Foursqu
 		ConfidenceScore:   0.0,
 	}
 
+	// The realtime Poller may already be keeping this place's status fresh
+	// in the background (see api/realtime), in which case that's a better
+	// answer than the "unknown" placeholder above - use it when available.
+	if cached := is.cachedRealTimeContext(ctx, place.FSQId); cached != nil {
+		realTimeContext = *cached
+	}
+
+	// Foursquare's features tree only gives us restroom/parking signals
+	// directly; everything else stays a conservative false rather than a
+	// guess, same as before this fell back to real data.
 	accessibilityIntel := models.AccessibilityIntelligence{
-		WheelchairAccessible: false, // Conservative default
+		WheelchairAccessible: false, // Conservative default - not reported by Foursquare
 		AccessibilityScore:   0.0,   // Unknown
 		Features: struct {
 			RampAccess           bool `json:"ramp_access"`
@@ -228,7 +383,10 @@ func (is *IntelligenceService) createBasicPlaceIntelligence(place models.Foursqu
 			HearingLoop          bool `json:"hearing_loop"`
 			WideEntrances        bool `json:"wide_entrances"`
 			AccessibleParking    bool `json:"accessible_parking"`
-		}{},
+		}{
+			AccessibleRestrooms: hasRestroom(place.Features),
+			AccessibleParking:   hasParking(place.Features),
+		},
 		InclusiveRecommendations: struct {
 			MobilityFriendlyAreas []string `json:"mobility_friendly_areas"`
 			SensoryAccommodations []string `json:"sensory_accommodations"`
@@ -248,8 +406,10 @@ func (is *IntelligenceService) createBasicPlaceIntelligence(place models.Foursqu
 		AccessibilityNotes:     []string{"Accessibility information not available - please contact venue"},
 	}
 
+	is.applyWeather(ctx, place, &realTimeContext, &businessIntel, &unifiedRecommendations)
+
 	return &models.PlaceIntelligence{
-		FSQId:      place.FSQPlaceId,
+		FSQId:      place.FSQId,
 		Name:       place.Name,
 		Location:   place.Location,
 		Categories: place.Categories,
@@ -259,18 +419,207 @@ func (is *IntelligenceService) createBasicPlaceIntelligence(place models.Foursqu
 		RealTimeContext:          realTimeContext,
 		AccessibilityIntelligence: accessibilityIntel,
 		UnifiedRecommendations:   unifiedRecommendations,
+		RawVenueDetails:           buildRawVenueDetails(place),
 
 		ProcessingTime: processingTime,
-		DataSources:    []string{"foursquare"},
+		DataSources:    placeDataSources(place, nil),
 		LastUpdated:    time.Now().UTC(),
 	}
 }
 
+// cachedRealTimeContext returns the realtime Poller's last-polled
+// RealTimeContext for fsqID, if the Poller has one cached, so a request for a
+// place someone is already subscribed to (see realtime.Poller.SubscribePlace)
+// can use that continuously-refreshed value instead of recomputing - or
+// guessing "unknown" - from scratch. Returns nil on a cache miss, with no
+// cacheService configured, or on any lookup error, all of which just leave
+// the caller's existing RealTimeContext in place.
+func (is *IntelligenceService) cachedRealTimeContext(ctx context.Context, fsqID string) *models.RealTimeContext {
+	if is.cacheService == nil {
+		return nil
+	}
+	rtc, err := is.cacheService.GetCachedRealTimeContext(ctx, fsqID)
+	if err != nil {
+		logrus.WithError(err).WithField("place_id", fsqID).Warn("Failed to read cached realtime context")
+		return nil
+	}
+	return rtc
+}
+
+// placeDataSources folds place.Sources - the PlacesProvider backend(s) that
+// contributed this place, set by FoursquareService/GooglePlacesService/
+// OSMProvider/MultiProvider - into intelligenceSources (the labels the
+// intelligence service itself reports, if any), deduped and in a stable
+// order. Falls back to "foursquare" if place.Sources is unset, since that
+// was this field's only possible value before MultiProvider started setting it.
+func placeDataSources(place models.FoursquarePlace, intelligenceSources []string) []string {
+	placeSources := place.Sources
+	if len(placeSources) == 0 {
+		placeSources = []string{"foursquare"}
+	}
+
+	seen := make(map[string]bool, len(placeSources)+len(intelligenceSources))
+	sources := make([]string, 0, len(placeSources)+len(intelligenceSources))
+	for _, s := range append(append([]string{}, placeSources...), intelligenceSources...) {
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		sources = append(sources, s)
+	}
+	return sources
+}
+
+// buildRawVenueDetails carries the richer Foursquare fields on place through
+// to PlaceIntelligence, so a consumer can inspect the original hours/photos/
+// features data the derived fields above came from. Returns nil if the
+// upstream request didn't fetch any of them, rather than an all-zero struct.
+func buildRawVenueDetails(place models.FoursquarePlace) *models.RawVenueDetails {
+	if place.Hours == nil && place.HoursPopular == nil && place.Popularity == 0 &&
+		place.Price == 0 && place.Rating == 0 && place.Stats == nil &&
+		place.Photos == nil && place.Tips == nil && place.SocialMedia == nil &&
+		place.Chains == nil && place.Features == nil {
+		return nil
+	}
+
+	return &models.RawVenueDetails{
+		Hours:        place.Hours,
+		HoursPopular: place.HoursPopular,
+		Popularity:   place.Popularity,
+		Price:        place.Price,
+		Rating:       place.Rating,
+		Stats:        place.Stats,
+		Photos:       place.Photos,
+		Tips:         place.Tips,
+		SocialMedia:  place.SocialMedia,
+		Chains:       place.Chains,
+		Features:     place.Features,
+	}
+}
+
+// priceRangeLabel maps Foursquare's 1-4 price tier to the same "$"..."$$$$"
+// labels the intelligence service uses, falling back to "unknown" when
+// Foursquare didn't report a price.
+func priceRangeLabel(price int) string {
+	switch price {
+	case 1:
+		return "$"
+	case 2:
+		return "$$"
+	case 3:
+		return "$$$"
+	case 4:
+		return "$$$$"
+	default:
+		return "unknown"
+	}
+}
+
+// atmosphereLabel buckets Foursquare's rating into a coarse atmosphere
+// label. This is a rough heuristic for the no-AI-service fallback path, not
+// a replacement for the intelligence service's own classification.
+func atmosphereLabel(rating float64) string {
+	switch {
+	case rating >= 4.5:
+		return "upscale"
+	case rating >= 3.5:
+		return "casual"
+	case rating > 0:
+		return "budget-friendly"
+	default:
+		return "unknown"
+	}
+}
+
+// popularityScoreFromPlace maps Foursquare's rating (0-10) onto the same
+// 0-10 scale BusinessIntelligence.PopularityScore uses elsewhere, falling
+// back to a neutral 5.0 when Foursquare didn't report a rating.
+func popularityScoreFromPlace(place models.FoursquarePlace) float64 {
+	if place.Rating > 0 {
+		return place.Rating
+	}
+	return 5.0
+}
+
+// sentimentScoreFromRating maps Foursquare's 0-10 rating onto a 0-5
+// sentiment scale, falling back to a neutral 3.5 when unrated.
+func sentimentScoreFromRating(rating float64) float64 {
+	if rating <= 0 {
+		return 3.5
+	}
+	return rating / 2
+}
+
+// specialtiesFromFeatures reads the meal/alcohol flags Foursquare reported
+// into a short specialties list, e.g. ["breakfast", "full bar"].
+func specialtiesFromFeatures(features *models.PlaceFeatures) []string {
+	specialties := []string{}
+	if features == nil || features.FoodAndDrink == nil {
+		return specialties
+	}
+
+	if meals := features.FoodAndDrink.Meals; meals != nil {
+		type mealFlag struct {
+			label string
+			flag  *bool
+		}
+		for _, m := range []mealFlag{
+			{"breakfast", meals.Breakfast},
+			{"brunch", meals.Brunch},
+			{"lunch", meals.Lunch},
+			{"dinner", meals.Dinner},
+			{"dessert", meals.Dessert},
+		} {
+			if m.flag != nil && *m.flag {
+				specialties = append(specialties, m.label)
+			}
+		}
+	}
+
+	if alcohol := features.FoodAndDrink.Alcohol; alcohol != nil {
+		if alcohol.FullBar != nil && *alcohol.FullBar {
+			specialties = append(specialties, "full bar")
+		} else if alcohol.BeerAndWine != nil && *alcohol.BeerAndWine {
+			specialties = append(specialties, "beer and wine")
+		}
+	}
+
+	return specialties
+}
+
+// hasRestroom reports whether Foursquare's features tree flags a restroom.
+func hasRestroom(features *models.PlaceFeatures) bool {
+	if features == nil || features.Amenities == nil || features.Amenities.Restroom == nil {
+		return false
+	}
+	return *features.Amenities.Restroom
+}
+
+// hasParking reports whether Foursquare's features tree flags any form of
+// parking (lot, street, valet, public, or garage).
+func hasParking(features *models.PlaceFeatures) bool {
+	if features == nil || features.Amenities == nil || features.Amenities.Parking == nil {
+		return false
+	}
+	p := features.Amenities.Parking
+	for _, flag := range []*bool{p.ParkingLot, p.StreetParking, p.ValetParking, p.PublicLot, p.ParkingGarage} {
+		if flag != nil && *flag {
+			return true
+		}
+	}
+	return false
+}
+
 // HealthCheck verifies connectivity to intelligence service
-func (is *IntelligenceService) HealthCheck() error {
+func (is *IntelligenceService) HealthCheck(ctx context.Context) error {
 	url := fmt.Sprintf("%s/health", is.baseURL)
-	
-	resp, err := is.httpClient.Get(url)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create intelligence health check request: %w", err)
+	}
+
+	resp, err := is.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("intelligence service health check failed: %w", err)
 	}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"placeintel-pro/api/models"
+	intelligencev1 "placeintel-pro/api/proto/intelligence/v1"
+)
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// grpcIntelligenceClient wraps the generated gRPC stub for the intelligence
+// service. It is only constructed when INTELLIGENCE_TRANSPORT=grpc; HTTP
+// remains the default so existing deployments are unaffected.
+type grpcIntelligenceClient struct {
+	conn   *grpc.ClientConn
+	client intelligencev1.IntelligenceServiceClient
+}
+
+func newGRPCIntelligenceClient(addr string) (*grpcIntelligenceClient, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial intelligence gRPC service: %w", err)
+	}
+
+	return &grpcIntelligenceClient{
+		conn:   conn,
+		client: intelligencev1.NewIntelligenceServiceClient(conn),
+	}, nil
+}
+
+func (g *grpcIntelligenceClient) Close() error {
+	return g.conn.Close()
+}
+
+func (g *grpcIntelligenceClient) enhance(ctx context.Context, place models.FoursquarePlace, features []string) (*IntelligenceResponse, error) {
+	resp, err := g.client.Enhance(ctx, &intelligencev1.EnhanceRequest{
+		Place:    toProtoPlace(place),
+		Features: features,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("grpc enhance call failed: %w", err)
+	}
+
+	// EnhancePlaceWithIntelligence already knows the place's own identity
+	// fields, so only the intelligence sub-messages are pulled off the
+	// response here; enhanceStream (below) has no such caller and needs
+	// fromProtoPlaceIntelligence's full conversion instead.
+	intel := fromProtoPlaceIntelligence(resp.Intelligence)
+	return &IntelligenceResponse{
+		BusinessIntelligence:      intel.BusinessIntelligence,
+		RealTimeContext:           intel.RealTimeContext,
+		AccessibilityIntelligence: intel.AccessibilityIntelligence,
+		UnifiedRecommendations:    intel.UnifiedRecommendations,
+		DataSources:               intel.DataSources,
+	}, nil
+}
+
+// enhanceStream pipelines a batch of places through the server-streaming
+// EnhancePlaces RPC instead of firing N concurrent HTTP requests behind a
+// semaphore: the stream itself provides backpressure, so results come back
+// as each place finishes rather than waiting on the slowest of a fixed batch.
+func (g *grpcIntelligenceClient) enhanceStream(ctx context.Context, places []models.FoursquarePlace) ([]models.PlaceIntelligence, error) {
+	stream, err := g.client.EnhancePlaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open EnhancePlaces stream: %w", err)
+	}
+
+	sendErrCh := make(chan error, 1)
+	go func() {
+		for _, place := range places {
+			if err := stream.Send(toProtoPlace(place)); err != nil {
+				sendErrCh <- err
+				return
+			}
+		}
+		sendErrCh <- stream.CloseSend()
+	}()
+
+	results := make([]models.PlaceIntelligence, 0, len(places))
+	for {
+		pbIntel, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("EnhancePlaces stream recv failed: %w", err)
+		}
+		results = append(results, fromProtoPlaceIntelligence(pbIntel))
+	}
+
+	if err := <-sendErrCh; err != nil {
+		return nil, fmt.Errorf("EnhancePlaces stream send failed: %w", err)
+	}
+
+	return results, nil
+}
+
+func toProtoPlace(place models.FoursquarePlace) *intelligencev1.Place {
+	categories := make([]*intelligencev1.Category, 0, len(place.Categories))
+	for _, cat := range place.Categories {
+		categories = append(categories, &intelligencev1.Category{Id: int32(cat.Id), Name: cat.Name})
+	}
+
+	return &intelligencev1.Place{
+		FsqId: place.FSQId,
+		Name:  place.Name,
+		Location: &intelligencev1.Location{
+			Address:     place.Location.Address,
+			Country:     place.Location.Country,
+			CrossStreet: place.Location.CrossStreet,
+			Locality:    place.Location.Locality,
+			Postcode:    place.Location.Postcode,
+			Region:      place.Location.Region,
+			Lat:         place.Location.Latitude,
+			Lng:         place.Location.Longitude,
+		},
+		Categories: categories,
+		Distance:   int32(place.Distance),
+	}
+}
+
+// fromProtoPlaceIntelligence converts a gRPC PlaceIntelligence message back
+// into a full models.PlaceIntelligence, including the place identity fields
+// (FsqId/Name/Location/Categories/Distance) that toProtoPlace sent alongside
+// it - the streaming EnhancePlaces path has no local models.FoursquarePlace
+// to copy those from, unlike enhance's single-request path above.
+func fromProtoPlaceIntelligence(pb *intelligencev1.PlaceIntelligence) models.PlaceIntelligence {
+	return models.PlaceIntelligence{
+		FSQId:      pb.FsqId,
+		Name:       pb.Name,
+		Location:   fromProtoLocation(pb.Location),
+		Categories: fromProtoCategories(pb.Categories),
+		Distance:   int(pb.Distance),
+
+		BusinessIntelligence: models.BusinessIntelligence{
+			PopularityScore: pb.BusinessIntelligence.PopularityScore,
+			SentimentScore:  pb.BusinessIntelligence.SentimentScore,
+			Specialties:     pb.BusinessIntelligence.Specialties,
+			IdealFor:        pb.BusinessIntelligence.IdealFor,
+			PriceRange:      pb.BusinessIntelligence.PriceRange,
+			Atmosphere:      pb.BusinessIntelligence.Atmosphere,
+			TrendingScore:   pb.BusinessIntelligence.TrendingScore,
+		},
+		RealTimeContext: models.RealTimeContext{
+			CurrentStatus:     pb.RealTimeContext.CurrentStatus,
+			CrowdLevel:        pb.RealTimeContext.CrowdLevel,
+			BestVisitTimes:    pb.RealTimeContext.BestVisitTimes,
+			LiveEvents:        pb.RealTimeContext.LiveEvents,
+			EstimatedWaitTime: pb.RealTimeContext.EstimatedWaitTime,
+			WeatherImpact:     pb.RealTimeContext.WeatherImpact,
+			LastUpdated:       timestampToTime(pb.RealTimeContext.LastUpdated),
+			ConfidenceScore:   pb.RealTimeContext.ConfidenceScore,
+		},
+		AccessibilityIntelligence: models.AccessibilityIntelligence{
+			WheelchairAccessible: pb.AccessibilityIntelligence.WheelchairAccessible,
+			AccessibilityScore:   pb.AccessibilityIntelligence.AccessibilityScore,
+		},
+		UnifiedRecommendations: models.UnifiedRecommendations{
+			ConfidenceScore:        pb.UnifiedRecommendations.ConfidenceScore,
+			PersonalizedInsights:   pb.UnifiedRecommendations.PersonalizedInsights,
+			AlternativeSuggestions: pb.UnifiedRecommendations.AlternativeSuggestions,
+			OptimalVisitStrategy:   pb.UnifiedRecommendations.OptimalVisitStrategy,
+			AccessibilityNotes:     pb.UnifiedRecommendations.AccessibilityNotes,
+		},
+		DataSources: pb.DataSources,
+		LastUpdated: timestampToTime(pb.LastUpdated),
+	}
+}
+
+// fromProtoLocation mirrors toProtoPlace's Location conversion in reverse,
+// matching the anonymous struct shape models.FoursquarePlace.Location uses so
+// a PlaceIntelligence built from a gRPC response decodes the same way as one
+// built from an HTTP FoursquarePlace.
+func fromProtoLocation(loc *intelligencev1.Location) interface{} {
+	if loc == nil {
+		return nil
+	}
+	return struct {
+		Address     string  `json:"address"`
+		Country     string  `json:"country"`
+		CrossStreet string  `json:"cross_street"`
+		Locality    string  `json:"locality"`
+		Postcode    string  `json:"postcode"`
+		Region      string  `json:"region"`
+		Latitude    float64 `json:"lat"`
+		Longitude   float64 `json:"lng"`
+	}{
+		Address:     loc.Address,
+		Country:     loc.Country,
+		CrossStreet: loc.CrossStreet,
+		Locality:    loc.Locality,
+		Postcode:    loc.Postcode,
+		Region:      loc.Region,
+		Latitude:    loc.Lat,
+		Longitude:   loc.Lng,
+	}
+}
+
+// fromProtoCategories mirrors toProtoPlace's Categories conversion in
+// reverse. The gRPC Category message carries no icon, so the converted
+// categories only ever populate id/name.
+func fromProtoCategories(cats []*intelligencev1.Category) interface{} {
+	type category struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	categories := make([]category, 0, len(cats))
+	for _, cat := range cats {
+		categories = append(categories, category{Id: int(cat.Id), Name: cat.Name})
+	}
+	return categories
+}
+
+func timestampToTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func init() {
+	// Surface transport selection at startup so operators can confirm which
+	// path is active without grepping logs for the first request.
+	logrus.WithField("transport", getEnv("INTELLIGENCE_TRANSPORT", "http")).Debug("Intelligence service transport configured")
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"placeintel-pro/api/models"
+	"placeintel-pro/api/services/weather"
+)
+
+// newWeatherProviderFromEnv selects the weather.Provider backend from
+// WEATHER_PROVIDER (openweather|caiyun), mirroring newPlacesProvider's
+// PLACES_PROVIDER switch in main.go. Returns nil when unset, which leaves
+// WeatherImpact at its prior "none" default - weather enrichment is opt-in.
+// Every selected provider is wrapped in weather.NewCachedProvider so nearby
+// places within the same hour share a lookup.
+func newWeatherProviderFromEnv() weather.Provider {
+	switch getEnv("WEATHER_PROVIDER", "") {
+	case "caiyun":
+		return weather.NewCachedProvider(weather.NewCaiyunProvider(getEnv("CAIYUN_API_KEY", "")))
+	case "openweather":
+		return weather.NewCachedProvider(weather.NewOpenWeatherProvider(getEnv("OPENWEATHER_API_KEY", "")))
+	default:
+		return nil
+	}
+}
+
+// applyWeather queries the configured weather provider for place's location
+// and folds the result into realTimeContext.WeatherImpact,
+// businessIntel.TrendingScore, and unifiedRecs.OptimalVisitStrategy. A no-op
+// when no provider is configured or the fetch fails - WeatherImpact simply
+// keeps whatever value the caller already set.
+func (is *IntelligenceService) applyWeather(
+	ctx context.Context,
+	place models.FoursquarePlace,
+	realTimeContext *models.RealTimeContext,
+	businessIntel *models.BusinessIntelligence,
+	unifiedRecs *models.UnifiedRecommendations,
+) {
+	if is.weatherProvider == nil {
+		return
+	}
+
+	conditions, err := is.weatherProvider.Fetch(ctx, place.Location.Latitude, place.Location.Longitude, time.Now())
+	if err != nil {
+		logrus.WithError(err).WithField("place_id", place.FSQId).Warn("Failed to fetch weather conditions")
+		return
+	}
+
+	realTimeContext.WeatherImpact = weatherImpactString(conditions)
+	businessIntel.TrendingScore = trendingScoreWithWeather(businessIntel.TrendingScore, conditions)
+	unifiedRecs.OptimalVisitStrategy = visitStrategyWithWeather(unifiedRecs.OptimalVisitStrategy, conditions)
+}
+
+// weatherImpactString translates Conditions into the short, actionable
+// sentence RealTimeContext.WeatherImpact surfaces to callers.
+func weatherImpactString(c weather.Conditions) string {
+	if len(c.Alerts) > 0 {
+		return fmt.Sprintf("Weather alert in effect: %s", c.Alerts[0].Title)
+	}
+	if c.IsOutdoorBad {
+		return fmt.Sprintf("Outdoor seating not recommended: %s expected (%.0f%% precipitation chance)", c.Summary, c.PrecipProb*100)
+	}
+	return fmt.Sprintf("Conditions favorable for outdoor seating: %s, %.0f°C", c.Summary, c.TemperatureC)
+}
+
+// trendingScoreWithWeather dampens the trending score when conditions are
+// bad for visiting - a simple multiplier rather than a full model, same
+// rough-signal approach popularityScoreFromPlace already takes.
+func trendingScoreWithWeather(base float64, c weather.Conditions) float64 {
+	if c.IsOutdoorBad {
+		return base * 0.8
+	}
+	return base
+}
+
+// visitStrategyWithWeather overrides the default "contact venue" strategy
+// with a weather-aware one when conditions call for it, otherwise leaves
+// whatever strategy the caller already derived.
+func visitStrategyWithWeather(base string, c weather.Conditions) string {
+	if c.IsOutdoorBad {
+		return "Best visited during an indoor-friendly time; outdoor areas may be uncomfortable"
+	}
+	return base
+}
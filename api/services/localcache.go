@@ -0,0 +1,84 @@
+package services
+
+import (
+	"container/list"
+	"path"
+	"sync"
+)
+
+// defaultLocalCacheCapacity bounds how many hot keys each instance keeps in
+// process memory, evicting least-recently-used entries once full.
+const defaultLocalCacheCapacity = 2000
+
+type lruEntry struct {
+	key   string
+	value []byte
+}
+
+// localLRUCache sits in front of Redis inside CacheService so repeat reads
+// for the same hot key don't round-trip over the network at all. Entries are
+// evicted on local capacity pressure, or on a cross-instance invalidation
+// event received over the placeintel:invalidate channel.
+type localLRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+func newLocalLRUCache(capacity int) *localLRUCache {
+	return &localLRUCache{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (c *localLRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruEntry).value, true
+}
+
+func (c *localLRUCache) Set(key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value.(*lruEntry).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+
+	el := c.order.PushFront(&lruEntry{key: key, value: value})
+	c.items[key] = el
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest == nil {
+			break
+		}
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// DeleteMatching evicts every local entry whose key matches pattern, using
+// the same glob syntax InvalidateCache accepts (e.g. "search:*").
+func (c *localLRUCache) DeleteMatching(pattern string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, el := range c.items {
+		if matched, err := path.Match(pattern, key); err == nil && matched {
+			c.order.Remove(el)
+			delete(c.items, key)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"placeintel-pro/api/models"
+	"placeintel-pro/api/services/resilience"
+)
+
+const overpassAPIBase = "https://overpass-api.de/api/interpreter"
+
+// OSMProvider implements PlacesProvider against the OpenStreetMap Overpass
+// API, for self-hosted or no-API-key deployments.
+type OSMProvider struct {
+	httpClient *http.Client
+	breaker    *resilience.Breaker
+	retryCfg   resilience.RetryConfig
+}
+
+// NewOSMProvider creates a new OpenStreetMap/Overpass provider instance.
+func NewOSMProvider() *OSMProvider {
+	return &OSMProvider{
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+		breaker:    resilience.NewBreaker("osm", resilience.BreakerConfigFromEnv("OSM")),
+		retryCfg:   resilience.RetryConfigFromEnv("OSM"),
+	}
+}
+
+type overpassResponse struct {
+	Elements []overpassElement `json:"elements"`
+}
+
+type overpassElement struct {
+	Type string            `json:"type"`
+	ID   int64             `json:"id"`
+	Lat  float64           `json:"lat"`
+	Lon  float64           `json:"lon"`
+	Tags map[string]string `json:"tags"`
+}
+
+// SearchPlaces queries Overpass for nodes with an amenity/shop tag within
+// radius meters of the requested point, and normalizes the result into the
+// shared FoursquarePlace shape.
+func (o *OSMProvider) SearchPlaces(ctx context.Context, req models.PlaceSearchRequest) ([]models.FoursquarePlace, error) {
+	radius := req.Radius
+	if radius == 0 {
+		radius = DefaultRadius
+	}
+
+	query := fmt.Sprintf(`
+[out:json][timeout:25];
+(
+  node["amenity"](around:%d,%f,%f);
+  node["shop"](around:%d,%f,%f);
+);
+out body;
+`, radius, req.Latitude, req.Longitude, radius, req.Latitude, req.Longitude)
+
+	body, err := o.post(ctx, query, "search")
+	if err != nil {
+		return nil, fmt.Errorf("failed to search places via Overpass: %w", err)
+	}
+
+	var overpassResp overpassResponse
+	if err := json.Unmarshal(body, &overpassResp); err != nil {
+		return nil, fmt.Errorf("failed to parse Overpass response: %w", err)
+	}
+
+	places := make([]models.FoursquarePlace, 0, len(overpassResp.Elements))
+	for _, el := range overpassResp.Elements {
+		name := el.Tags["name"]
+		if name == "" {
+			continue
+		}
+		place := toOSMPlace(el, req.Latitude, req.Longitude)
+		place.Sources = []string{o.Name()}
+		places = append(places, place)
+	}
+
+	limit := req.Limit
+	if limit > 0 && limit < len(places) {
+		places = places[:limit]
+	}
+
+	return places, nil
+}
+
+// GetPlaceDetails looks up a single OSM node by id via Overpass. OSM place
+// IDs are expected in the form "osm:<node_id>", mirroring how SearchPlaces
+// populates FSQId for OSM results.
+func (o *OSMProvider) GetPlaceDetails(ctx context.Context, placeID string) (*FoursquarePlaceDetails, error) {
+	nodeID := strings.TrimPrefix(placeID, "osm:")
+	query := fmt.Sprintf(`[out:json][timeout:25];node(%s);out body;`, nodeID)
+
+	body, err := o.post(ctx, query, "details")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get place details via Overpass: %w", err)
+	}
+
+	var overpassResp overpassResponse
+	if err := json.Unmarshal(body, &overpassResp); err != nil {
+		return nil, fmt.Errorf("failed to parse Overpass response: %w", err)
+	}
+	if len(overpassResp.Elements) == 0 {
+		return nil, fmt.Errorf("osm node %s not found", nodeID)
+	}
+
+	el := overpassResp.Elements[0]
+	details := &FoursquarePlaceDetails{
+		FSQId:   fmt.Sprintf("osm:%d", el.ID),
+		Name:    el.Tags["name"],
+		Tel:     el.Tags["phone"],
+		Website: el.Tags["website"],
+	}
+	details.Location.Address = strings.TrimSpace(el.Tags["addr:housenumber"] + " " + el.Tags["addr:street"])
+	details.Location.Locality = el.Tags["addr:city"]
+	details.Location.Postcode = el.Tags["addr:postcode"]
+	details.Location.Latitude = el.Lat
+	details.Location.Longitude = el.Lon
+
+	return details, nil
+}
+
+func toOSMPlace(el overpassElement, originLat, originLng float64) models.FoursquarePlace {
+	place := models.FoursquarePlace{
+		FSQId: fmt.Sprintf("osm:%d", el.ID),
+		Name:  el.Tags["name"],
+	}
+	place.Location.Address = strings.TrimSpace(el.Tags["addr:housenumber"] + " " + el.Tags["addr:street"])
+	place.Location.Locality = el.Tags["addr:city"]
+	place.Location.Postcode = el.Tags["addr:postcode"]
+	place.Location.Latitude = el.Lat
+	place.Location.Longitude = el.Lon
+	place.Distance = int(haversineDistanceMeters(originLat, originLng, el.Lat, el.Lon))
+
+	tagValue := el.Tags["amenity"]
+	if tagValue == "" {
+		tagValue = el.Tags["shop"]
+	}
+	if tagValue != "" {
+		place.Categories = append(place.Categories, struct {
+			Id   int    `json:"id"`
+			Name string `json:"name"`
+			Icon struct {
+				Prefix string `json:"prefix"`
+				Suffix string `json:"suffix"`
+			} `json:"icon"`
+		}{
+			Id:   0,
+			Name: normalizeCategory("osm", tagValue),
+		})
+	}
+
+	return place
+}
+
+// post performs a retried, circuit-broken Overpass query.
+func (o *OSMProvider) post(ctx context.Context, query, endpoint string) ([]byte, error) {
+	var body []byte
+
+	err := o.breaker.Execute(func() error {
+		return resilience.Do(ctx, "osm", o.retryCfg, classifyFoursquareError, func() error {
+			req, err := http.NewRequestWithContext(ctx, "POST", overpassAPIBase, strings.NewReader("data="+query))
+			if err != nil {
+				return fmt.Errorf("failed to create request: %w", err)
+			}
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			resp, err := o.httpClient.Do(req)
+			if err != nil {
+				return fmt.Errorf("failed to make request: %w", err)
+			}
+			defer resp.Body.Close()
+
+			b, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return fmt.Errorf("failed to read response body: %w", err)
+			}
+
+			if resp.StatusCode != http.StatusOK {
+				return &statusError{statusCode: resp.StatusCode, retryAfter: parseRetryAfter(resp.Header.Get("Retry-After")), body: string(b)}
+			}
+
+			body = b
+			return nil
+		})
+	})
+
+	if err != nil {
+		logrus.WithFields(logrus.Fields{"endpoint": endpoint, "error": err}).Error("Overpass API request failed")
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// HealthCheck verifies connectivity to the Overpass API.
+func (o *OSMProvider) HealthCheck(ctx context.Context) error {
+	_, err := o.post(ctx, `[out:json][timeout:5];node(1);out body;`, "health")
+	if err != nil {
+		return fmt.Errorf("osm health check failed: %w", err)
+	}
+	return nil
+}
+
+// Name identifies this provider in MultiProvider's fusion output and
+// per-provider health reporting. Matches the breaker name above.
+func (o *OSMProvider) Name() string {
+	return "osm"
+}
+
+var _ PlacesProvider = (*OSMProvider)(nil)
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"context"
+
+	"placeintel-pro/api/models"
+)
+
+// PlacesProvider is the common surface every places backend exposes, so the
+// HTTP handlers and intelligence pipeline can stay provider-agnostic. Every
+// method takes the caller's context.Context so a client disconnect or
+// per-request deadline cancels the in-flight upstream call. FoursquareService,
+// GooglePlacesService and OSMProvider all implement it; MultiProvider
+// composes several of them behind the same interface.
+type PlacesProvider interface {
+	SearchPlaces(ctx context.Context, req models.PlaceSearchRequest) ([]models.FoursquarePlace, error)
+	GetPlaceDetails(ctx context.Context, placeID string) (*FoursquarePlaceDetails, error)
+	HealthCheck(ctx context.Context) error
+}
+
+// compile-time assertion that FoursquareService still satisfies the
+// interface after any refactor.
+var _ PlacesProvider = (*FoursquareService)(nil)
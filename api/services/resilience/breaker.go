@@ -0,0 +1,185 @@
+// Package resilience provides a shared circuit breaker and retry helper for
+// outbound dependencies (Foursquare, the intelligence service, Redis), so a
+// hiccup in one of them degrades gracefully instead of producing 5xx storms.
+package resilience
+
+import (
+	"sync"
+	"time"
+)
+
+// State is the circuit breaker state machine: closed (normal), open
+// (shedding load), half-open (probing whether the dependency recovered).
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// BreakerConfig controls when a Breaker trips and how it recovers.
+type BreakerConfig struct {
+	// FailureRatio is the fraction of requests in the current window that
+	// must fail before the breaker trips open.
+	FailureRatio float64
+	// MinRequests is the minimum number of requests observed in the window
+	// before FailureRatio is evaluated, so a single failure on a cold start
+	// doesn't trip the breaker.
+	MinRequests int
+	// ResetTimeout is how long the breaker stays open before allowing a
+	// single half-open probe request through.
+	ResetTimeout time.Duration
+	// HalfOpenMaxRequests caps how many probe requests are allowed through
+	// while half-open.
+	HalfOpenMaxRequests int
+}
+
+// DefaultBreakerConfig is a reasonable starting point for upstream HTTP
+// dependencies: trip after at least 10 requests with a >=50% failure rate,
+// recover after 30s.
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		FailureRatio:        0.5,
+		MinRequests:         10,
+		ResetTimeout:        30 * time.Second,
+		HalfOpenMaxRequests: 1,
+	}
+}
+
+// Breaker is a closed/open/half-open circuit breaker, modeled on the classic
+// Hystrix/gobreaker state machine, with Prometheus counters for trips,
+// successes and rejections so operators can see a dependency being shed.
+type Breaker struct {
+	name string
+	cfg  BreakerConfig
+
+	mu             sync.Mutex
+	state          State
+	requests       int
+	failures       int
+	openedAt       time.Time
+	halfOpenInUse  int
+}
+
+// NewBreaker creates a named breaker and registers it with the default
+// registry so it shows up in health snapshots.
+func NewBreaker(name string, cfg BreakerConfig) *Breaker {
+	b := &Breaker{name: name, cfg: cfg, state: StateClosed}
+	DefaultRegistry.register(b)
+	return b
+}
+
+// ErrOpen is returned by Execute when the breaker is open and shedding load.
+type ErrOpen struct{ Name string }
+
+func (e *ErrOpen) Error() string {
+	return "circuit breaker open for " + e.Name
+}
+
+// Execute runs fn if the breaker allows it, recording the outcome. It
+// returns ErrOpen without calling fn if the dependency is currently being
+// shed.
+func (b *Breaker) Execute(fn func() error) error {
+	if !b.allow() {
+		breakerRejectionsTotal.WithLabelValues(b.name).Inc()
+		return &ErrOpen{Name: b.name}
+	}
+
+	err := fn()
+	b.recordResult(err == nil)
+	return err
+}
+
+// State returns the breaker's current state for health reporting.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.maybeTransitionToHalfOpen()
+	return b.state
+}
+
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.maybeTransitionToHalfOpen()
+
+	switch b.state {
+	case StateOpen:
+		return false
+	case StateHalfOpen:
+		if b.halfOpenInUse >= b.cfg.HalfOpenMaxRequests {
+			return false
+		}
+		b.halfOpenInUse++
+		return true
+	default:
+		return true
+	}
+}
+
+// maybeTransitionToHalfOpen must be called with mu held.
+func (b *Breaker) maybeTransitionToHalfOpen() {
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.cfg.ResetTimeout {
+		b.state = StateHalfOpen
+		b.halfOpenInUse = 0
+		b.requests = 0
+		b.failures = 0
+	}
+}
+
+func (b *Breaker) recordResult(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen {
+		if success {
+			b.reset()
+			breakerSuccessesTotal.WithLabelValues(b.name).Inc()
+			return
+		}
+		b.trip()
+		return
+	}
+
+	b.requests++
+	if success {
+		breakerSuccessesTotal.WithLabelValues(b.name).Inc()
+		return
+	}
+
+	b.failures++
+	if b.requests >= b.cfg.MinRequests && float64(b.failures)/float64(b.requests) >= b.cfg.FailureRatio {
+		b.trip()
+	}
+}
+
+// trip must be called with mu held.
+func (b *Breaker) trip() {
+	if b.state != StateOpen {
+		breakerTripsTotal.WithLabelValues(b.name).Inc()
+	}
+	b.state = StateOpen
+	b.openedAt = time.Now()
+	b.halfOpenInUse = 0
+}
+
+// reset must be called with mu held.
+func (b *Breaker) reset() {
+	b.state = StateClosed
+	b.requests = 0
+	b.failures = 0
+	b.halfOpenInUse = 0
+}
@@ -0,0 +1,73 @@
+package resilience
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// BreakerConfigFromEnv builds a BreakerConfig for a named dependency from
+// env vars prefixed with <PREFIX>_BREAKER_, falling back to
+// DefaultBreakerConfig for anything unset. This lets operators tune
+// Foursquare, Intelligence and Redis breakers independently, e.g.
+// FOURSQUARE_BREAKER_FAILURE_RATIO=0.3.
+func BreakerConfigFromEnv(prefix string) BreakerConfig {
+	cfg := DefaultBreakerConfig()
+
+	if v, ok := envFloat(prefix + "_BREAKER_FAILURE_RATIO"); ok {
+		cfg.FailureRatio = v
+	}
+	if v, ok := envInt(prefix + "_BREAKER_MIN_REQUESTS"); ok {
+		cfg.MinRequests = v
+	}
+	if v, ok := envInt(prefix + "_BREAKER_RESET_TIMEOUT_MS"); ok {
+		cfg.ResetTimeout = time.Duration(v) * time.Millisecond
+	}
+	if v, ok := envInt(prefix + "_BREAKER_HALF_OPEN_MAX_REQUESTS"); ok {
+		cfg.HalfOpenMaxRequests = v
+	}
+
+	return cfg
+}
+
+// RetryConfigFromEnv builds a RetryConfig for a named dependency from env
+// vars prefixed with <PREFIX>_RETRY_.
+func RetryConfigFromEnv(prefix string) RetryConfig {
+	cfg := DefaultRetryConfig()
+
+	if v, ok := envInt(prefix + "_RETRY_MAX_ATTEMPTS"); ok {
+		cfg.MaxAttempts = v
+	}
+	if v, ok := envInt(prefix + "_RETRY_BASE_DELAY_MS"); ok {
+		cfg.BaseDelay = time.Duration(v) * time.Millisecond
+	}
+	if v, ok := envInt(prefix + "_RETRY_MAX_DELAY_MS"); ok {
+		cfg.MaxDelay = time.Duration(v) * time.Millisecond
+	}
+
+	return cfg
+}
+
+func envFloat(key string) (float64, bool) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+func envInt(key string) (int, bool) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return 0, false
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
@@ -0,0 +1,28 @@
+package resilience
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	breakerTripsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "placeintel_breaker_trips_total",
+		Help: "Number of times a circuit breaker has tripped open, by dependency.",
+	}, []string{"dependency"})
+
+	breakerRejectionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "placeintel_breaker_rejections_total",
+		Help: "Number of calls rejected because a circuit breaker was open, by dependency.",
+	}, []string{"dependency"})
+
+	breakerSuccessesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "placeintel_breaker_successes_total",
+		Help: "Number of calls that completed successfully through a circuit breaker, by dependency.",
+	}, []string{"dependency"})
+
+	retriesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "placeintel_retries_total",
+		Help: "Number of retry attempts issued for a retriable error, by dependency.",
+	}, []string{"dependency"})
+)
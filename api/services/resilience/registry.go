@@ -0,0 +1,33 @@
+package resilience
+
+import "sync"
+
+// registry tracks every breaker created via NewBreaker so the health
+// endpoint can report dependency state without each service having to pass
+// its breaker around separately.
+type registry struct {
+	mu       sync.RWMutex
+	breakers map[string]*Breaker
+}
+
+// DefaultRegistry is the process-wide breaker registry.
+var DefaultRegistry = &registry{breakers: make(map[string]*Breaker)}
+
+func (r *registry) register(b *Breaker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.breakers[b.name] = b
+}
+
+// Snapshot returns the current state of every registered breaker, keyed by
+// dependency name, for surfacing on /api/v1/health.
+func (r *registry) Snapshot() map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	states := make(map[string]string, len(r.breakers))
+	for name, b := range r.breakers {
+		states[name] = b.State().String()
+	}
+	return states
+}
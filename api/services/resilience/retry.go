@@ -0,0 +1,76 @@
+package resilience
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// RetryConfig controls exponential backoff with jitter for retriable errors.
+type RetryConfig struct {
+	// MaxAttempts is the total number of tries, including the first.
+	MaxAttempts int
+	// BaseDelay is the starting backoff delay, doubled on each retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff delay.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryConfig retries twice more after the initial attempt, backing
+// off from 100ms up to 2s.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    2 * time.Second,
+	}
+}
+
+// Classify inspects an error from an attempt and reports whether it's worth
+// retrying, plus an optional server-requested delay (e.g. from a
+// Retry-After header) to honor instead of the computed backoff.
+type Classify func(err error) (retriable bool, retryAfter time.Duration)
+
+// Do runs fn, retrying on retriable errors per cfg with exponential backoff
+// and jitter. It stops early if ctx is done or classify reports a
+// non-retriable error.
+func Do(ctx context.Context, dependency string, cfg RetryConfig, classify Classify, fn func() error) error {
+	var err error
+	delay := cfg.BaseDelay
+
+	for attempt := 1; attempt <= cfg.MaxAttempts; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+
+		retriable, retryAfter := classify(err)
+		if !retriable || attempt == cfg.MaxAttempts {
+			return err
+		}
+
+		wait := delay
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		wait += time.Duration(rand.Int63n(int64(cfg.BaseDelay)))
+		if wait > cfg.MaxDelay {
+			wait = cfg.MaxDelay
+		}
+
+		retriesTotal.WithLabelValues(dependency).Inc()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+
+		delay *= 2
+		if delay > cfg.MaxDelay {
+			delay = cfg.MaxDelay
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"placeintel-pro/api/models"
+)
+
+// searchLockTTL bounds how long a single-flight lock is held, in case the
+// instance that acquired it crashes before releasing - a later request for
+// the same key won't be stuck waiting past this bound.
+const searchLockTTL = 10 * time.Second
+
+// searchLockPollInterval and searchLockMaxWait bound how long an instance
+// that lost the race polls the cache for the winner's result before giving up
+// and serving the request itself.
+const (
+	searchLockPollInterval = 50 * time.Millisecond
+	searchLockMaxWait      = 500 * time.Millisecond
+)
+
+func searchLockKey(cacheKey string) string {
+	return "lock:search:" + cacheKey
+}
+
+// TryAcquireSearchLock attempts to become the single instance that populates
+// cacheKey on a cold miss, via SET NX PX. If another instance already holds
+// the lock, acquired is false and the caller should poll with
+// WaitForCachedSearchResults instead of calling the places provider itself.
+func (cs *CacheService) TryAcquireSearchLock(ctx context.Context, cacheKey string) (release func(), acquired bool, err error) {
+	lockKey := searchLockKey(cacheKey)
+
+	ok, err := cs.client.SetNX(ctx, lockKey, "1", searchLockTTL).Result()
+	if err != nil {
+		return func() {}, false, fmt.Errorf("failed to acquire search lock: %w", err)
+	}
+	if !ok {
+		return func() {}, false, nil
+	}
+
+	return func() { cs.client.Del(ctx, lockKey) }, true, nil
+}
+
+// WaitForCachedSearchResults polls the search cache for up to
+// searchLockMaxWait, for an instance that lost TryAcquireSearchLock and is
+// waiting on the winning instance to populate cacheKey. ok is false if
+// nothing showed up in time, in which case the caller should fall through and
+// serve the request itself rather than wait indefinitely.
+func (cs *CacheService) WaitForCachedSearchResults(ctx context.Context, cacheKey string) (results []models.PlaceIntelligence, ok bool) {
+	deadline := time.Now().Add(searchLockMaxWait)
+
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-time.After(searchLockPollInterval):
+		}
+
+		if cached, err := cs.GetCachedSearchResults(ctx, cacheKey); err == nil && cached != nil {
+			return cached, true
+		}
+	}
+
+	return nil, false
+}
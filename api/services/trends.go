@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/sirupsen/logrus"
+
+	"placeintel-pro/api/models"
+)
+
+// Trend sorted-set key prefixes. Members are ZINCRBY'd on every recorded
+// event so counts accumulate without a read-modify-write round trip.
+const (
+	trendsCategoryPrefix = "trends:cat:"
+	trendsAreaPrefix     = "trends:area:"
+	trendsPlacePrefix    = "trends:place:"
+	trendsHoursPrefix    = "trends:hours:"
+)
+
+// trendsCellLevel is the S2 level category/place counters are keyed at
+// (~5km cells, same band floor as s2LevelForRadius's largest radius).
+// trendsAreaLevel is one band coarser, so popular-areas rollups can report
+// which trendsCellLevel cell within a wider region saw the most activity.
+const (
+	trendsCellLevel = 10
+	trendsAreaLevel = 8
+)
+
+// Trend counters expire on their own; nothing ever explicitly deletes them.
+const (
+	trendsCategoryTTL = 7 * 24 * time.Hour
+	trendsPlaceTTL    = 30 * 24 * time.Hour
+	trendsHoursTTL    = 30 * 24 * time.Hour
+)
+
+// TrendEvent is a single place interaction worth counting toward trending
+// categories, areas, and hours - emitted on every successful search result
+// or direct intelligence lookup.
+type TrendEvent struct {
+	PlaceID    string
+	Categories []string
+	Latitude   float64
+	Longitude  float64
+	Timestamp  time.Time
+}
+
+// TrendsSnapshot is the aggregated answer to "what's trending near here".
+type TrendsSnapshot struct {
+	TrendingCategories []string
+	PopularAreas       []string
+	PeakHours          []string
+}
+
+// TrendPoint is a single bucketed count in a place's activity history.
+type TrendPoint struct {
+	Bucket string
+	Count  int64
+}
+
+// TrendsBackend is the storage behind TrendsService. RedisTrendsBackend is
+// the default; a heavier deployment can swap in an Elasticsearch- or
+// ClickHouse-backed implementation without touching any call site.
+type TrendsBackend interface {
+	RecordEvent(ctx context.Context, event TrendEvent) error
+	Trends(ctx context.Context, lat, lng float64) (TrendsSnapshot, error)
+	History(ctx context.Context, placeID string) ([]TrendPoint, error)
+}
+
+// TrendsService tracks place/category activity so GetTrends can answer with
+// real aggregated data instead of a hardcoded stub.
+type TrendsService struct {
+	backend TrendsBackend
+}
+
+// NewTrendsService creates a TrendsService backed by Redis sorted sets,
+// sharing the connection pool cacheService already holds.
+func NewTrendsService(client *redis.Client) *TrendsService {
+	return &TrendsService{backend: &RedisTrendsBackend{client: client}}
+}
+
+// NewTrendsServiceWithBackend creates a TrendsService against an arbitrary
+// TrendsBackend.
+func NewTrendsServiceWithBackend(backend TrendsBackend) *TrendsService {
+	return &TrendsService{backend: backend}
+}
+
+// RecordPlaceEvent records a place interaction toward category, area, place,
+// and hour-of-day trend counters. Failures are logged, not returned - a
+// dropped trend event should never fail the request that triggered it.
+func (ts *TrendsService) RecordPlaceEvent(ctx context.Context, place models.FoursquarePlace) {
+	categories := make([]string, 0, len(place.Categories))
+	for _, category := range place.Categories {
+		categories = append(categories, category.Name)
+	}
+
+	event := TrendEvent{
+		PlaceID:    place.FSQId,
+		Categories: categories,
+		Latitude:   place.Location.Latitude,
+		Longitude:  place.Location.Longitude,
+		Timestamp:  time.Now().UTC(),
+	}
+
+	if err := ts.backend.RecordEvent(ctx, event); err != nil {
+		logrus.WithError(err).WithField("place_id", place.FSQId).Warn("Failed to record trend event")
+	}
+}
+
+// GetTrends returns trending categories, popular areas, and peak hours for
+// the S2 cell containing (lat, lng).
+func (ts *TrendsService) GetTrends(ctx context.Context, lat, lng float64) (TrendsSnapshot, error) {
+	return ts.backend.Trends(ctx, lat, lng)
+}
+
+// GetPlaceHistory returns a place's hour-of-day activity distribution for
+// charting.
+func (ts *TrendsService) GetPlaceHistory(ctx context.Context, placeID string) ([]TrendPoint, error) {
+	return ts.backend.History(ctx, placeID)
+}
+
+// RedisTrendsBackend stores trend counters in Redis sorted sets, keyed by
+// S2 cell and time bucket so recent activity can be read back with a single
+// ZREVRANGE instead of scanning raw events.
+type RedisTrendsBackend struct {
+	client *redis.Client
+}
+
+// RecordEvent increments the category, area, place, and hour-of-day sorted
+// sets for event in a single pipeline.
+func (b *RedisTrendsBackend) RecordEvent(ctx context.Context, event TrendEvent) error {
+	cell := s2TokenAt(event.Latitude, event.Longitude, trendsCellLevel)
+	areaCell := s2TokenAt(event.Latitude, event.Longitude, trendsAreaLevel)
+	hourBucket := event.Timestamp.Format("2006010215")
+	dayBucket := event.Timestamp.Format("20060102")
+	hourOfDay := fmt.Sprintf("%02d", event.Timestamp.Hour())
+
+	pipe := b.client.Pipeline()
+
+	for _, category := range event.Categories {
+		key := trendsCategoryPrefix + cell + ":" + hourBucket
+		pipe.ZIncrBy(ctx, key, 1, category)
+		pipe.Expire(ctx, key, trendsCategoryTTL)
+	}
+
+	areaKey := trendsAreaPrefix + areaCell + ":" + dayBucket
+	pipe.ZIncrBy(ctx, areaKey, 1, cell)
+	pipe.Expire(ctx, areaKey, trendsPlaceTTL)
+
+	placeKey := trendsPlacePrefix + cell + ":" + dayBucket
+	pipe.ZIncrBy(ctx, placeKey, 1, event.PlaceID)
+	pipe.Expire(ctx, placeKey, trendsPlaceTTL)
+
+	hoursKey := trendsHoursPrefix + event.PlaceID
+	pipe.ZIncrBy(ctx, hoursKey, 1, hourOfDay)
+	pipe.Expire(ctx, hoursKey, trendsHoursTTL)
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Trends reads the current hour/day buckets for (lat, lng)'s S2 cell and its
+// enclosing area cell to build a TrendsSnapshot.
+func (b *RedisTrendsBackend) Trends(ctx context.Context, lat, lng float64) (TrendsSnapshot, error) {
+	now := time.Now().UTC()
+	cell := s2TokenAt(lat, lng, trendsCellLevel)
+	areaCell := s2TokenAt(lat, lng, trendsAreaLevel)
+
+	categories, err := b.topMembers(ctx, trendsCategoryPrefix+cell+":"+now.Format("2006010215"), 5)
+	if err != nil {
+		return TrendsSnapshot{}, fmt.Errorf("failed to read trending categories: %w", err)
+	}
+
+	areas, err := b.topMembers(ctx, trendsAreaPrefix+areaCell+":"+now.Format("20060102"), 5)
+	if err != nil {
+		return TrendsSnapshot{}, fmt.Errorf("failed to read popular areas: %w", err)
+	}
+
+	placeKeys, err := b.topMembers(ctx, trendsPlacePrefix+cell+":"+now.Format("20060102"), 10)
+	if err != nil {
+		return TrendsSnapshot{}, fmt.Errorf("failed to read place activity: %w", err)
+	}
+	peakHours, err := b.peakHoursForPlaces(ctx, placeKeys)
+	if err != nil {
+		return TrendsSnapshot{}, fmt.Errorf("failed to read peak hours: %w", err)
+	}
+
+	return TrendsSnapshot{
+		TrendingCategories: categories,
+		PopularAreas:       areas,
+		PeakHours:          peakHours,
+	}, nil
+}
+
+// History returns a place's full hour-of-day distribution, sorted by hour.
+func (b *RedisTrendsBackend) History(ctx context.Context, placeID string) ([]TrendPoint, error) {
+	results, err := b.client.ZRevRangeWithScores(ctx, trendsHoursPrefix+placeID, 0, -1).Result()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to read trend history for place %s: %w", placeID, err)
+	}
+
+	points := make([]TrendPoint, 0, len(results))
+	for _, z := range results {
+		if bucket, ok := z.Member.(string); ok {
+			points = append(points, TrendPoint{Bucket: bucket, Count: int64(z.Score)})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Bucket < points[j].Bucket })
+	return points, nil
+}
+
+// peakHoursForPlaces merges the hour-of-day distributions of the busiest
+// places in an area into a single ranked list of peak hour buckets.
+func (b *RedisTrendsBackend) peakHoursForPlaces(ctx context.Context, placeIDs []string) ([]string, error) {
+	totals := make(map[string]float64)
+	for _, placeID := range placeIDs {
+		results, err := b.client.ZRevRangeWithScores(ctx, trendsHoursPrefix+placeID, 0, -1).Result()
+		if err != nil && err != redis.Nil {
+			return nil, err
+		}
+		for _, z := range results {
+			if hour, ok := z.Member.(string); ok {
+				totals[hour] += z.Score
+			}
+		}
+	}
+
+	hours := make([]string, 0, len(totals))
+	for hour := range totals {
+		hours = append(hours, hour)
+	}
+	sort.Slice(hours, func(i, j int) bool { return totals[hours[i]] > totals[hours[j]] })
+
+	if len(hours) > 3 {
+		hours = hours[:3]
+	}
+	return hours, nil
+}
+
+func (b *RedisTrendsBackend) topMembers(ctx context.Context, key string, n int) ([]string, error) {
+	results, err := b.client.ZRevRangeWithScores(ctx, key, 0, int64(n-1)).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	members := make([]string, 0, len(results))
+	for _, z := range results {
+		if member, ok := z.Member.(string); ok {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
@@ -0,0 +1,73 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// roundedCacheTTL bounds how long a cached lookup is served before a fresh
+// Fetch is required, independent of the wrapped provider's own freshness.
+const roundedCacheTTL = 30 * time.Minute
+
+// cacheEntry pairs a cached Conditions value with when it was stored, so
+// CachedProvider can expire it independently of Conditions.FetchedAt (which
+// reflects the caller's requested time, not the cache write time).
+type cacheEntry struct {
+	conditions Conditions
+	storedAt   time.Time
+}
+
+// CachedProvider wraps a Provider and shares lookups across nearby places:
+// lat/lng is rounded to ~1.1km precision (2 decimal places) and when is
+// rounded down to the hour, so places a block apart asking within the same
+// hour hit the same cache entry instead of each paying for their own call.
+type CachedProvider struct {
+	inner Provider
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// NewCachedProvider wraps inner with the rounded lat/lng+hour cache.
+func NewCachedProvider(inner Provider) *CachedProvider {
+	return &CachedProvider{
+		inner: inner,
+		cache: make(map[string]cacheEntry),
+	}
+}
+
+// Fetch returns a cached Conditions for the rounded key if one is still
+// within roundedCacheTTL, otherwise calls through to inner and caches the
+// result.
+func (c *CachedProvider) Fetch(ctx context.Context, lat, lng float64, when time.Time) (Conditions, error) {
+	key := roundedCacheKey(lat, lng, when)
+
+	c.mu.Lock()
+	entry, ok := c.cache[key]
+	c.mu.Unlock()
+	if ok && time.Since(entry.storedAt) < roundedCacheTTL {
+		return entry.conditions, nil
+	}
+
+	conditions, err := c.inner.Fetch(ctx, lat, lng, when)
+	if err != nil {
+		return Conditions{}, err
+	}
+
+	c.mu.Lock()
+	c.cache[key] = cacheEntry{conditions: conditions, storedAt: time.Now()}
+	c.mu.Unlock()
+
+	return conditions, nil
+}
+
+// roundedCacheKey rounds lat/lng to 2 decimal places (~1.1km at the
+// equator) and when down to the hour, so nearby places within the same
+// hour share a cache entry.
+func roundedCacheKey(lat, lng float64, when time.Time) string {
+	round := func(v float64) float64 { return math.Round(v*100) / 100 }
+	return fmt.Sprintf("%.2f,%.2f,%s", round(lat), round(lng), when.UTC().Format("2006-01-02T15"))
+}
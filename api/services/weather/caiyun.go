@@ -0,0 +1,192 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"placeintel-pro/api/services/resilience"
+)
+
+const caiyunAPIBase = "https://api.caiyunapp.com/v2.6"
+
+// CaiyunProvider implements Provider against Caiyun's realtime+hourly+daily
+// weather API, which additionally exposes alerts - unlike OpenWeatherProvider,
+// which only reports current conditions.
+type CaiyunProvider struct {
+	apiKey     string
+	httpClient *http.Client
+	breaker    *resilience.Breaker
+	retryCfg   resilience.RetryConfig
+}
+
+// NewCaiyunProvider creates a new Caiyun-backed Provider instance.
+func NewCaiyunProvider(apiKey string) *CaiyunProvider {
+	return &CaiyunProvider{
+		apiKey: apiKey,
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+		breaker:  resilience.NewBreaker("caiyun", resilience.BreakerConfigFromEnv("CAIYUN")),
+		retryCfg: resilience.RetryConfigFromEnv("CAIYUN"),
+	}
+}
+
+type caiyunResponse struct {
+	Result struct {
+		Realtime struct {
+			Temperature float64 `json:"temperature"`
+			Skycon      string  `json:"skycon"`
+			Precipitation struct {
+				Probability float64 `json:"probability"`
+			} `json:"precipitation"`
+		} `json:"realtime"`
+		Hourly struct {
+			Temperature []caiyunHourlyValue `json:"temperature"`
+			Skycon      []caiyunSkyconValue `json:"skycon"`
+			Precipitation struct {
+				Probability []caiyunHourlyValue `json:"probability"`
+			} `json:"precipitation"`
+		} `json:"hourly"`
+		Daily struct {
+			Temperature []caiyunDailyTemp   `json:"temperature"`
+			Skycon      []caiyunSkyconValue `json:"skycon"`
+		} `json:"daily"`
+		Alert struct {
+			Content []caiyunAlertContent `json:"content"`
+		} `json:"alert"`
+	} `json:"result"`
+}
+
+type caiyunHourlyValue struct {
+	Datetime time.Time `json:"datetime"`
+	Value    float64   `json:"value"`
+}
+
+type caiyunDailyTemp struct {
+	Date time.Time `json:"date"`
+	Max  float64   `json:"max"`
+}
+
+type caiyunSkyconValue struct {
+	Datetime time.Time `json:"datetime"`
+	Value    string    `json:"value"`
+}
+
+type caiyunAlertContent struct {
+	Title   string    `json:"title"`
+	Level   string    `json:"level"`
+	PubTime time.Time `json:"pubtime"`
+}
+
+// badSkycons are Caiyun's "skycon" codes that make outdoor seating a bad
+// idea, mirroring OpenWeatherProvider's badConditionCodes.
+var badSkycons = map[string]bool{
+	"RAIN":       true,
+	"HEAVY_RAIN": true,
+	"STORM_RAIN": true,
+	"SNOW":       true,
+	"HEAVY_SNOW": true,
+	"WIND":       true,
+}
+
+// Fetch queries Caiyun's realtime+hourly+daily+alert endpoint for lat/lng.
+// when is used only to stamp the returned Conditions.FetchedAt - Caiyun
+// always returns its full forecast window regardless of the requested time.
+func (p *CaiyunProvider) Fetch(ctx context.Context, lat, lng float64, when time.Time) (Conditions, error) {
+	reqURL := fmt.Sprintf("%s/%s/%s,%s/weather.json?alert=true&hourlysteps=24&dailysteps=3",
+		caiyunAPIBase, p.apiKey,
+		strconv.FormatFloat(lng, 'f', 6, 64), strconv.FormatFloat(lat, 'f', 6, 64))
+
+	var body []byte
+	err := p.breaker.Execute(func() error {
+		return resilience.Do(ctx, "caiyun", p.retryCfg, classifyWeatherError, func() error {
+			b, err := p.doRequest(ctx, reqURL)
+			if err != nil {
+				return err
+			}
+			body = b
+			return nil
+		})
+	})
+	if err != nil {
+		return Conditions{}, fmt.Errorf("failed to fetch caiyun conditions: %w", err)
+	}
+
+	var cyResp caiyunResponse
+	if err := json.Unmarshal(body, &cyResp); err != nil {
+		return Conditions{}, fmt.Errorf("failed to parse caiyun response: %w", err)
+	}
+
+	realtime := cyResp.Result.Realtime
+	conditions := Conditions{
+		Summary:      realtime.Skycon,
+		TemperatureC: realtime.Temperature,
+		PrecipProb:   realtime.Precipitation.Probability,
+		IsOutdoorBad: badSkycons[realtime.Skycon],
+		FetchedAt:    when,
+	}
+
+	for i, skycon := range cyResp.Result.Hourly.Skycon {
+		hour := HourlyForecast{
+			Time:      skycon.Datetime,
+			Condition: skycon.Value,
+		}
+		if i < len(cyResp.Result.Hourly.Temperature) {
+			hour.TemperatureC = cyResp.Result.Hourly.Temperature[i].Value
+		}
+		if i < len(cyResp.Result.Hourly.Precipitation.Probability) {
+			hour.PrecipProb = cyResp.Result.Hourly.Precipitation.Probability[i].Value
+		}
+		conditions.Hourly = append(conditions.Hourly, hour)
+	}
+
+	for i, skycon := range cyResp.Result.Daily.Skycon {
+		day := HourlyForecast{
+			Time:      skycon.Datetime,
+			Condition: skycon.Value,
+		}
+		if i < len(cyResp.Result.Daily.Temperature) {
+			day.TemperatureC = cyResp.Result.Daily.Temperature[i].Max
+		}
+		conditions.Daily = append(conditions.Daily, day)
+	}
+
+	for _, alert := range cyResp.Result.Alert.Content {
+		conditions.Alerts = append(conditions.Alerts, Alert{
+			Title:     alert.Title,
+			Severity:  alert.Level,
+			StartTime: alert.PubTime,
+		})
+	}
+
+	return conditions, nil
+}
+
+func (p *CaiyunProvider) doRequest(ctx context.Context, reqURL string) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create caiyun request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call caiyun: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read caiyun response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &statusError{statusCode: resp.StatusCode, body: string(body)}
+	}
+
+	return body, nil
+}
@@ -0,0 +1,159 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"placeintel-pro/api/services/resilience"
+)
+
+const openWeatherAPIBase = "https://api.openweathermap.org/data/2.5"
+
+// badConditionCodes are OpenWeather's "group 2xx/3xx/5xx/6xx" codes (storms,
+// drizzle, rain, snow) that make outdoor seating a bad idea.
+var badConditionCodes = map[string]bool{
+	"Thunderstorm": true,
+	"Drizzle":      true,
+	"Rain":         true,
+	"Snow":         true,
+}
+
+// OpenWeatherProvider implements Provider against OpenWeather's current
+// weather endpoint. It only reports current conditions - no hourly/daily
+// breakdown or alerts, unlike CaiyunProvider.
+type OpenWeatherProvider struct {
+	apiKey     string
+	httpClient *http.Client
+	breaker    *resilience.Breaker
+	retryCfg   resilience.RetryConfig
+}
+
+// NewOpenWeatherProvider creates a new OpenWeather-backed Provider instance.
+func NewOpenWeatherProvider(apiKey string) *OpenWeatherProvider {
+	return &OpenWeatherProvider{
+		apiKey: apiKey,
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+		breaker:  resilience.NewBreaker("openweather", resilience.BreakerConfigFromEnv("OPENWEATHER")),
+		retryCfg: resilience.RetryConfigFromEnv("OPENWEATHER"),
+	}
+}
+
+type openWeatherResponse struct {
+	Weather []struct {
+		Main        string `json:"main"`
+		Description string `json:"description"`
+	} `json:"weather"`
+	Main struct {
+		Temp float64 `json:"temp"`
+	} `json:"main"`
+	Pop float64 `json:"pop"`
+}
+
+// Fetch queries OpenWeather's current-weather endpoint for lat/lng. when is
+// ignored beyond stamping the returned Conditions.FetchedAt, since the free
+// current-weather endpoint has no forecast concept.
+func (p *OpenWeatherProvider) Fetch(ctx context.Context, lat, lng float64, when time.Time) (Conditions, error) {
+	params := url.Values{}
+	params.Set("lat", strconv.FormatFloat(lat, 'f', 6, 64))
+	params.Set("lon", strconv.FormatFloat(lng, 'f', 6, 64))
+	params.Set("units", "metric")
+	params.Set("appid", p.apiKey)
+
+	reqURL := fmt.Sprintf("%s/weather?%s", openWeatherAPIBase, params.Encode())
+
+	var body []byte
+	err := p.breaker.Execute(func() error {
+		return resilience.Do(ctx, "openweather", p.retryCfg, classifyWeatherError, func() error {
+			b, err := p.doRequest(ctx, reqURL)
+			if err != nil {
+				return err
+			}
+			body = b
+			return nil
+		})
+	})
+	if err != nil {
+		return Conditions{}, fmt.Errorf("failed to fetch openweather conditions: %w", err)
+	}
+
+	var owResp openWeatherResponse
+	if err := json.Unmarshal(body, &owResp); err != nil {
+		return Conditions{}, fmt.Errorf("failed to parse openweather response: %w", err)
+	}
+
+	summary := "clear"
+	isBad := false
+	if len(owResp.Weather) > 0 {
+		summary = owResp.Weather[0].Description
+		isBad = badConditionCodes[owResp.Weather[0].Main]
+	}
+
+	return Conditions{
+		Summary:      summary,
+		TemperatureC: owResp.Main.Temp,
+		PrecipProb:   owResp.Pop,
+		IsOutdoorBad: isBad,
+		FetchedAt:    when,
+	}, nil
+}
+
+func (p *OpenWeatherProvider) doRequest(ctx context.Context, reqURL string) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create openweather request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call openweather: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read openweather response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &statusError{statusCode: resp.StatusCode, body: string(body)}
+	}
+
+	return body, nil
+}
+
+// statusError carries the HTTP status from a non-200 weather provider
+// response, so classifyWeatherError can decide whether it's worth retrying
+// without re-parsing the response body.
+type statusError struct {
+	statusCode int
+	body       string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("weather provider returned status %d: %s", e.statusCode, e.body)
+}
+
+// classifyWeatherError treats 429/502/503/504 as retriable, everything else
+// (including network errors, which aren't a *statusError) as worth one more
+// try - weather lookups are short-lived and low-stakes enough that we don't
+// need Foursquare's more elaborate Retry-After honoring.
+func classifyWeatherError(err error) (bool, time.Duration) {
+	var se *statusError
+	if errors.As(err, &se) {
+		retriable := se.statusCode == http.StatusTooManyRequests ||
+			se.statusCode == http.StatusBadGateway ||
+			se.statusCode == http.StatusServiceUnavailable ||
+			se.statusCode == http.StatusGatewayTimeout
+		return retriable, 0
+	}
+	return true, 0
+}
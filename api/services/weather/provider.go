@@ -0,0 +1,52 @@
+// Package weather supplies near-term weather conditions the intelligence
+// pipeline folds into RealTimeContext.WeatherImpact, BusinessIntelligence's
+// trending score, and UnifiedRecommendations' visit strategy. Providers are
+// selected via config (WEATHER_PROVIDER), mirroring how services.PlacesProvider
+// is selected via PLACES_PROVIDER.
+package weather
+
+import (
+	"context"
+	"time"
+)
+
+// Alert is a single weather warning (heat, storm, flood, ...) covering a
+// time window, as returned by providers that expose them (e.g. Caiyun).
+type Alert struct {
+	Title     string    `json:"title"`
+	Severity  string    `json:"severity"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// HourlyForecast is one hour's outlook, used by providers with hourly
+// granularity to answer "will it rain in the next few hours".
+type HourlyForecast struct {
+	Time            time.Time `json:"time"`
+	TemperatureC    float64   `json:"temperature_c"`
+	PrecipProb      float64   `json:"precip_probability"`
+	Condition       string    `json:"condition"`
+}
+
+// Conditions is the provider-agnostic shape the intelligence pipeline
+// consumes. Providers that only report current conditions (e.g. a basic
+// OpenWeather call) leave Hourly/Daily/Alerts empty rather than nil-paneling
+// the caller; richer providers (e.g. Caiyun) populate them.
+type Conditions struct {
+	Summary      string           `json:"summary"`
+	TemperatureC float64          `json:"temperature_c"`
+	PrecipProb   float64          `json:"precip_probability"`
+	IsOutdoorBad bool             `json:"is_outdoor_bad"`
+	Hourly       []HourlyForecast `json:"hourly,omitempty"`
+	Daily        []HourlyForecast `json:"daily,omitempty"`
+	Alerts       []Alert          `json:"alerts,omitempty"`
+	FetchedAt    time.Time        `json:"fetched_at"`
+}
+
+// Provider fetches weather conditions for a location at (or near) a point
+// in time. Implementations should treat when as "now" unless they support
+// forecasting; Fetch is still passed it explicitly so a caching wrapper can
+// key on it without reaching into provider internals.
+type Provider interface {
+	Fetch(ctx context.Context, lat, lng float64, when time.Time) (Conditions, error)
+}